@@ -1,23 +1,60 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 	"tournament-manager/internal/database"
+	"tournament-manager/internal/logging"
 	"tournament-manager/internal/server"
+	"tournament-manager/internal/tournament"
+	"tournament-manager/internal/webhooks"
 )
 
 func main() {
-	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+	logger := slog.New(logging.NewContextHandler(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 		AddSource: true,
 		Level:     slog.LevelDebug,
-	}))
+	})))
 	slog.SetDefault(logger)
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	if err := database.Init(); err != nil {
 		slog.Error(err.Error())
 		return
 	}
+	defer database.Close()
+
+	tournament.Manager = tournament.NewTournamentManager(tournament.NewPostgresRepositories(database.DB))
+
+	if err := tournament.Manager.LoadActiveTournaments(ctx); err != nil {
+		slog.Error(err.Error())
+		return
+	}
+
+	webhooks.Start(ctx)
+
+	// Scheduled tournaments start on their own, including any that came due
+	// while the server was down.
+	tournament.Manager.StartScheduler(ctx, 30*time.Second)
+
+	if err := server.StartServer(ctx); err != nil {
+		slog.Error(err.Error())
+	}
 
-	server.StartServer()
+	// Nothing is submitting results anymore, so save every active
+	// tournament and let the last webhooks go out before closing the pool.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), server.ShutdownTimeout())
+	defer cancel()
+	tournament.Manager.Shutdown(shutdownCtx)
+	webhooks.Shutdown(shutdownCtx)
 }
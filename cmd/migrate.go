@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"tournament-manager/internal/database"
+)
+
+const migrateUsage = `usage: tournament-manager migrate <command>
+
+commands:
+  status      list migrations and whether they have been applied
+  up          apply every pending migration
+  down [n]    roll back the latest n migrations (default 1)`
+
+// runMigrate handles the migrate subcommand and returns the exit code.
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	if err := database.Connect(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "status":
+		status, err := database.Status(ctx)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		for _, migration := range status {
+			applied := "pending"
+			if migration.AppliedAt != nil {
+				applied = "applied " + migration.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Printf("%04d  %-30s %s\n", migration.Version, migration.Name, applied)
+		}
+
+	case "up":
+		if err := database.Migrate(ctx); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				fmt.Fprintf(os.Stderr, "invalid number of migrations: %v\n", args[1])
+				return 2
+			}
+			steps = n
+		}
+
+		if err := database.Rollback(ctx, steps); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	return 0
+}
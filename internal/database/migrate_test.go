@@ -0,0 +1,27 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+
+	if len(migrations) == 0 {
+		t.Fatalf("expected at least one migration")
+	}
+
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Errorf("unexpected migration version, expected %v, got %v", i+1, migration.Version)
+		}
+
+		if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+			t.Errorf("migration %v_%v is missing its up or down sql", migration.Version, migration.Name)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS GameResult;
+DROP TABLE IF EXISTS Player;
+DROP TABLE IF EXISTS Tournament;
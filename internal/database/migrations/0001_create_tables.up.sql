@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS Tournament (
+    id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    date INT NOT NULL,
+    format VARCHAR(50)
+);
+
+CREATE TABLE IF NOT EXISTS Player (
+    id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    ign VARCHAR(100) NOT NULL,
+    discord_name VARCHAR(100) NOT NULL,
+    tournament_id UUID NOT NULL,
+    personal_best INT,
+    FOREIGN KEY (tournament_id) REFERENCES Tournament(id)
+);
+
+CREATE TABLE IF NOT EXISTS GameResult (
+    game_id VARCHAR(20) NOT NULL,
+    tournament_id UUID NOT NULL,
+    player_id UUID NOT NULL,
+    position INT,
+    time INT,
+    FOREIGN KEY (tournament_id) REFERENCES Tournament(id),
+    FOREIGN KEY (player_id) REFERENCES Player(id)
+);
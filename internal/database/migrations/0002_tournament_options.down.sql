@@ -0,0 +1 @@
+ALTER TABLE Tournament DROP COLUMN IF EXISTS options;
@@ -0,0 +1 @@
+ALTER TABLE Tournament ADD COLUMN IF NOT EXISTS options JSONB NOT NULL DEFAULT '{}';
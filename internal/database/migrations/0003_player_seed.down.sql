@@ -0,0 +1 @@
+ALTER TABLE Player DROP COLUMN IF EXISTS seed;
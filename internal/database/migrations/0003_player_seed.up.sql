@@ -0,0 +1 @@
+ALTER TABLE Player ADD COLUMN IF NOT EXISTS seed INT;
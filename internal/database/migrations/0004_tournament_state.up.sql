@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS TournamentState (
+    tournament_id UUID PRIMARY KEY,
+    state JSONB NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (tournament_id) REFERENCES Tournament(id)
+);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS Placement;
+DROP TABLE IF EXISTS MatchRecord;
+
+ALTER TABLE Tournament DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE Tournament DROP COLUMN IF EXISTS winner;
+ALTER TABLE Tournament DROP COLUMN IF EXISTS status;
@@ -0,0 +1,24 @@
+ALTER TABLE Tournament ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'scheduled';
+ALTER TABLE Tournament ADD COLUMN IF NOT EXISTS winner VARCHAR(100);
+ALTER TABLE Tournament ADD COLUMN IF NOT EXISTS completed_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS MatchRecord (
+    tournament_id UUID NOT NULL,
+    match_id VARCHAR(20) NOT NULL,
+    round INT NOT NULL,
+    bracket VARCHAR(20),
+    players TEXT[] NOT NULL,
+    winner VARCHAR(100),
+    best_of INT NOT NULL DEFAULT 1,
+    PRIMARY KEY (tournament_id, match_id),
+    FOREIGN KEY (tournament_id) REFERENCES Tournament(id)
+);
+
+CREATE TABLE IF NOT EXISTS Placement (
+    tournament_id UUID NOT NULL,
+    player_id UUID NOT NULL,
+    position INT NOT NULL,
+    PRIMARY KEY (tournament_id, player_id),
+    FOREIGN KEY (tournament_id) REFERENCES Tournament(id),
+    FOREIGN KEY (player_id) REFERENCES Player(id)
+);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS Webhook (
+    id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    tournament_id UUID NOT NULL,
+    url TEXT NOT NULL,
+    events TEXT[] NOT NULL DEFAULT '{}',
+    secret VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (tournament_id) REFERENCES Tournament(id)
+);
+
+CREATE TABLE IF NOT EXISTS WebhookDelivery (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id UUID NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    attempt INT NOT NULL,
+    status_code INT,
+    error TEXT,
+    delivered BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (webhook_id) REFERENCES Webhook(id) ON DELETE CASCADE
+);
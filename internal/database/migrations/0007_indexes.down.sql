@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS webhook_tournament_idx;
+DROP INDEX IF EXISTS game_result_tournament_idx;
+DROP INDEX IF EXISTS player_tournament_idx;
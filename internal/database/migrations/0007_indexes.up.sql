@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS player_tournament_idx ON Player (tournament_id);
+CREATE INDEX IF NOT EXISTS game_result_tournament_idx ON GameResult (tournament_id, game_id);
+CREATE INDEX IF NOT EXISTS webhook_tournament_idx ON Webhook (tournament_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS ResultSubmission (
+    id BIGSERIAL PRIMARY KEY,
+    tournament_id UUID NOT NULL,
+    game_id VARCHAR(20) NOT NULL,
+    idempotency_key VARCHAR(100),
+    players TEXT[] NOT NULL,
+    times BIGINT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (tournament_id) REFERENCES Tournament(id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS result_submission_key_idx ON ResultSubmission (tournament_id, idempotency_key) WHERE idempotency_key IS NOT NULL;
+CREATE INDEX IF NOT EXISTS result_submission_game_idx ON ResultSubmission (tournament_id, game_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS ResultCorrection;
+
+ALTER TABLE ResultSubmission DROP COLUMN IF EXISTS voided_at;
+
+ALTER TABLE Tournament DROP COLUMN IF EXISTS seeding;
@@ -0,0 +1,21 @@
+ALTER TABLE Tournament ADD COLUMN IF NOT EXISTS seeding TEXT[];
+
+ALTER TABLE ResultSubmission ADD COLUMN IF NOT EXISTS voided_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS ResultCorrection (
+    id BIGSERIAL PRIMARY KEY,
+    tournament_id UUID NOT NULL,
+    submission_id BIGINT NOT NULL,
+    game_id VARCHAR(20) NOT NULL,
+    action VARCHAR(10) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    players_before TEXT[] NOT NULL,
+    times_before BIGINT[] NOT NULL,
+    players_after TEXT[],
+    times_after BIGINT[],
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (tournament_id) REFERENCES Tournament(id),
+    FOREIGN KEY (submission_id) REFERENCES ResultSubmission(id)
+);
+
+CREATE INDEX IF NOT EXISTS result_correction_tournament_idx ON ResultCorrection (tournament_id);
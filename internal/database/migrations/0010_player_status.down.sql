@@ -0,0 +1,3 @@
+ALTER TABLE ResultSubmission DROP COLUMN IF EXISTS kind;
+
+ALTER TABLE Player DROP COLUMN IF EXISTS status;
@@ -0,0 +1,3 @@
+ALTER TABLE Player ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'registered';
+
+ALTER TABLE ResultSubmission ADD COLUMN IF NOT EXISTS kind VARCHAR(20) NOT NULL DEFAULT 'result';
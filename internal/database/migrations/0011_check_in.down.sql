@@ -0,0 +1,4 @@
+ALTER TABLE Player DROP COLUMN IF EXISTS checked_in_at;
+
+ALTER TABLE Tournament DROP COLUMN IF EXISTS checkin_closes;
+ALTER TABLE Tournament DROP COLUMN IF EXISTS checkin_opens;
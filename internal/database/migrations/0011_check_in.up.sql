@@ -0,0 +1,4 @@
+ALTER TABLE Tournament ADD COLUMN IF NOT EXISTS checkin_opens INT;
+ALTER TABLE Tournament ADD COLUMN IF NOT EXISTS checkin_closes INT;
+
+ALTER TABLE Player ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMPTZ;
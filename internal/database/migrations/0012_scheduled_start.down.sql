@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tournament_status_date_idx;
+
+ALTER TABLE Tournament DROP COLUMN IF EXISTS start_when_checkin_closes;
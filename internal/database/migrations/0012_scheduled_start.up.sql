@@ -0,0 +1,3 @@
+ALTER TABLE Tournament ADD COLUMN IF NOT EXISTS start_when_checkin_closes BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS tournament_status_date_idx ON Tournament (status, date);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS MatchDeadline (
+    tournament_id UUID NOT NULL,
+    match_id VARCHAR(20) NOT NULL,
+    round INT NOT NULL,
+    opened_at TIMESTAMPTZ NOT NULL,
+    deadline TIMESTAMPTZ NOT NULL,
+    overdue BOOLEAN NOT NULL DEFAULT FALSE,
+    auto_forfeit BOOLEAN NOT NULL DEFAULT FALSE,
+    reported TEXT[] NOT NULL DEFAULT '{}',
+    PRIMARY KEY (tournament_id, match_id),
+    FOREIGN KEY (tournament_id) REFERENCES Tournament(id)
+);
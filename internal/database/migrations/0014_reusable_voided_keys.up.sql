@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS result_submission_key_idx;
+
+CREATE UNIQUE INDEX IF NOT EXISTS result_submission_key_idx ON ResultSubmission (tournament_id, idempotency_key) WHERE idempotency_key IS NOT NULL AND voided_at IS NULL;
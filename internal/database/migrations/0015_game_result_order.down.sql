@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS game_result_tournament_idx;
+CREATE INDEX IF NOT EXISTS game_result_tournament_idx ON GameResult (tournament_id, game_id);
+
+ALTER TABLE GameResult DROP COLUMN IF EXISTS id;
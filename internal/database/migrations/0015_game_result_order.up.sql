@@ -0,0 +1,6 @@
+-- Game results are listed in the order they were recorded. Rows that are
+-- already stored are numbered in the order they sit in the table.
+ALTER TABLE GameResult ADD COLUMN IF NOT EXISTS id BIGSERIAL;
+
+DROP INDEX IF EXISTS game_result_tournament_idx;
+CREATE INDEX IF NOT EXISTS game_result_tournament_idx ON GameResult (tournament_id, id);
@@ -0,0 +1,59 @@
+-- Times used to be parsed with the tenths digit stored as milliseconds, so
+-- 2:00.5 was saved as 120005. Scale every stored time to real milliseconds.
+-- Old times never have more than a single digit past the second.
+UPDATE Player
+SET personal_best = personal_best - personal_best % 1000 + personal_best % 1000 * 100
+WHERE personal_best % 1000 < 10;
+
+UPDATE GameResult
+SET time = time - time % 1000 + time % 1000 * 100
+WHERE time % 1000 < 10;
+
+UPDATE ResultSubmission
+SET times = ARRAY(
+    SELECT CASE WHEN t % 1000 < 10 THEN t - t % 1000 + t % 1000 * 100 ELSE t END
+    FROM unnest(times) WITH ORDINALITY AS u(t, i)
+    ORDER BY i
+);
+
+UPDATE ResultCorrection
+SET times_before = ARRAY(
+        SELECT CASE WHEN t % 1000 < 10 THEN t - t % 1000 + t % 1000 * 100 ELSE t END
+        FROM unnest(times_before) WITH ORDINALITY AS u(t, i)
+        ORDER BY i
+    ),
+    times_after = CASE WHEN times_after IS NULL THEN NULL ELSE ARRAY(
+        SELECT CASE WHEN t % 1000 < 10 THEN t - t % 1000 + t % 1000 * 100 ELSE t END
+        FROM unnest(times_after) WITH ORDINALITY AS u(t, i)
+        ORDER BY i
+    ) END;
+
+-- Saved swiss and free for all heats states keep their own copy of the
+-- game results.
+UPDATE TournamentState
+SET state = jsonb_set(state, '{Results}', (
+    SELECT COALESCE(jsonb_agg(
+        CASE WHEN (r->>'Time')::bigint % 1000 < 10
+            THEN jsonb_set(r, '{Time}', to_jsonb((r->>'Time')::bigint - (r->>'Time')::bigint % 1000 + (r->>'Time')::bigint % 1000 * 100))
+            ELSE r
+        END ORDER BY i), '[]'::jsonb)
+    FROM jsonb_array_elements(state->'Results') WITH ORDINALITY AS e(r, i)
+))
+WHERE jsonb_typeof(state->'Results') = 'array';
+
+-- Saved round robin states keep running time totals, which can't be scaled
+-- game by game, so they are rebuilt from the game results updated above.
+UPDATE TournamentState ts
+SET state = ts.state || jsonb_build_object(
+    'TotalTime', COALESCE((
+        SELECT jsonb_object_agg(p.ign, t.total)
+        FROM (SELECT player_id, SUM(time) AS total FROM GameResult WHERE tournament_id = ts.tournament_id GROUP BY player_id) t
+        JOIN Player p ON p.id = t.player_id
+    ), '{}'::jsonb),
+    'BestTime', COALESCE((
+        SELECT jsonb_object_agg(p.ign, t.best)
+        FROM (SELECT player_id, MIN(time) AS best FROM GameResult WHERE tournament_id = ts.tournament_id GROUP BY player_id) t
+        JOIN Player p ON p.id = t.player_id
+    ), '{}'::jsonb)
+)
+WHERE jsonb_typeof(ts.state->'TotalTime') = 'object';
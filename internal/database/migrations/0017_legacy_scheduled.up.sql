@@ -0,0 +1,7 @@
+-- Migration 0005 marked every tournament that existed then as scheduled,
+-- including ones that were played long ago. Only tournaments still ahead of
+-- their date start on their own, the rest are left for an admin.
+ALTER TABLE Tournament ADD COLUMN IF NOT EXISTS auto_start BOOLEAN NOT NULL DEFAULT TRUE;
+
+UPDATE Tournament SET auto_start = FALSE
+WHERE status = 'scheduled' AND date < EXTRACT(EPOCH FROM now());
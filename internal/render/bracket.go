@@ -0,0 +1,181 @@
+// Package render draws a tournament bracket as a standalone SVG image, or an
+// HTML page embedding one, from the structured bracket every format provides.
+package render
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"tournament-manager/internal/tournament/formats"
+	"tournament-manager/internal/util"
+)
+
+const (
+	columnWidth = 240
+	columnGap   = 48
+	rowHeight   = 24
+	matchGap    = 20
+	headerSize  = 40
+	margin      = 20
+)
+
+var roundTitles = map[string]string{
+	formats.BracketWinners:    "Winners Round %d",
+	formats.BracketLosers:     "Losers Round %d",
+	formats.BracketGrandFinal: "Grand Final %d",
+}
+
+// box is where a match ends up in the image.
+type box struct {
+	x, y  int
+	match formats.BracketMatch
+}
+
+func roundTitle(round formats.BracketRound) string {
+	if title, ok := roundTitles[round.Bracket]; ok {
+		return fmt.Sprintf(title, round.Round)
+	}
+	return fmt.Sprintf("Round %d", round.Round)
+}
+
+// matchHeight is the height of a match box, with one row per slot.
+func matchHeight(match formats.BracketMatch) int {
+	return max(len(match.Slots), 1) * rowHeight
+}
+
+// columnHeight is the height a round needs to give its matches and byes an
+// equal share each that fits its largest match.
+func columnHeight(round formats.BracketRound) int {
+	largest := rowHeight
+	for _, match := range round.Matches {
+		largest = max(largest, matchHeight(match))
+	}
+	return (len(round.Matches) + len(round.Byes)) * (largest + matchGap)
+}
+
+func slotLabel(slot formats.BracketSlot) string {
+	label := slot.Player
+	if slot.Position > 0 {
+		label = fmt.Sprintf("%d. %s", slot.Position, label)
+	} else if slot.Seed > 0 {
+		label = fmt.Sprintf("(%d) %s", slot.Seed, label)
+	}
+	return label
+}
+
+func slotScore(slot formats.BracketSlot, bestOf int) string {
+	times := make([]string, len(slot.Times))
+	for i, time := range slot.Times {
+		times[i] = util.FormatTime(time)
+	}
+
+	score := strings.Join(times, " ")
+	if bestOf > 1 {
+		score = strings.TrimSpace(fmt.Sprintf("%d  %s", slot.Wins, score))
+	}
+	return score
+}
+
+// SVG draws the bracket with one column per round. Matches are spread over
+// the height of the tallest round so elimination brackets line up, finished
+// matches highlight their winner and feeder matches are joined to the slot
+// they lead into.
+func SVG(bracket formats.Bracket) string {
+	height := 0
+	for _, round := range bracket.Rounds {
+		height = max(height, columnHeight(round))
+	}
+
+	width := margin*2 + max(len(bracket.Rounds)*(columnWidth+columnGap)-columnGap, columnWidth)
+	totalHeight := margin*2 + headerSize + max(height, rowHeight)
+	if bracket.Complete {
+		totalHeight += headerSize
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="13">`+"\n", width, totalHeight, width, totalHeight)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#1e1f22"/>`+"\n", width, totalHeight)
+
+	boxes := make(map[string]box)
+	for i, round := range bracket.Rounds {
+		x := margin + i*(columnWidth+columnGap)
+		fmt.Fprintf(&b, `<text x="%d" y="%d" fill="#b5bac1" font-weight="bold">%s</text>`+"\n", x, margin+16, html.EscapeString(roundTitle(round)))
+
+		// Centre every entry in an equal share of the tallest column.
+		entries := len(round.Matches) + len(round.Byes)
+		share := max(height, rowHeight) / max(entries, 1)
+		top := margin + headerSize
+
+		for j, match := range round.Matches {
+			y := top + j*share + (share-matchHeight(match)-matchGap)/2
+			boxes[match.ID] = box{x: x, y: y, match: match}
+			writeMatch(&b, x, y, match)
+		}
+
+		for j, bye := range round.Byes {
+			y := top + (len(round.Matches)+j)*share + (share-rowHeight-matchGap)/2
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="4" fill="#2b2d31" stroke="#4e5058" stroke-dasharray="4 3"/>`+"\n", x, y, columnWidth, rowHeight)
+			fmt.Fprintf(&b, `<text x="%d" y="%d" fill="#949ba4">%s</text>`+"\n", x+8, y+17, html.EscapeString(slotLabel(bye)))
+			fmt.Fprintf(&b, `<text x="%d" y="%d" fill="#949ba4" text-anchor="end">BYE</text>`+"\n", x+columnWidth-8, y+17)
+		}
+	}
+
+	for _, round := range bracket.Rounds {
+		for _, match := range round.Matches {
+			to := boxes[match.ID]
+			for i, slot := range match.Slots {
+				from, ok := boxes[slot.FeederMatch]
+				if !ok || from.x >= to.x {
+					continue
+				}
+
+				x1, y1 := from.x+columnWidth, from.y+matchHeight(from.match)/2
+				x2, y2 := to.x, to.y+i*rowHeight+rowHeight/2
+				midX := x2 - columnGap/2
+				fmt.Fprintf(&b, `<path d="M%d %d H%d V%d H%d" fill="none" stroke="#4e5058"/>`+"\n", x1, y1, midX, y2, x2)
+			}
+		}
+	}
+
+	if bracket.Complete {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" fill="#23a55a" font-size="16" font-weight="bold">Winner: %s</text>`+"\n", margin, totalHeight-margin, html.EscapeString(bracket.Winner))
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+func writeMatch(b *strings.Builder, x, y int, match formats.BracketMatch) {
+	fmt.Fprintf(b, `<g id="%s">`+"\n", html.EscapeString(match.ID))
+	fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" rx="4" fill="#2b2d31" stroke="#4e5058"/>`+"\n", x, y, columnWidth, matchHeight(match))
+
+	for i, slot := range match.Slots {
+		rowY := y + i*rowHeight
+		fill, weight := "#dbdee1", "normal"
+
+		if match.Finished && slot.Player == match.Winner {
+			fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" fill="#23a55a" fill-opacity="0.25"/>`+"\n", x+1, rowY+1, columnWidth-2, rowHeight-2)
+			fill, weight = "#ffffff", "bold"
+		} else if match.Finished {
+			fill = "#949ba4"
+		}
+
+		fmt.Fprintf(b, `<text x="%d" y="%d" fill="%s" font-weight="%s">%s</text>`+"\n", x+8, rowY+17, fill, weight, html.EscapeString(slotLabel(slot)))
+		fmt.Fprintf(b, `<text x="%d" y="%d" fill="%s" text-anchor="end">%s</text>`+"\n", x+columnWidth-8, rowY+17, fill, html.EscapeString(slotScore(slot, match.BestOf)))
+	}
+
+	b.WriteString("</g>\n")
+}
+
+// HTML wraps the SVG of a bracket in a page that can be opened in a browser
+// or used as a stream overlay.
+func HTML(bracket formats.Bracket, title string) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(title))
+	b.WriteString("<style>body { margin: 0; background: #1e1f22; } svg { display: block; }</style>\n")
+	b.WriteString("</head>\n<body>\n")
+	b.WriteString(SVG(bracket))
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
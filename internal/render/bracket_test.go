@@ -0,0 +1,34 @@
+package render
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"tournament-manager/internal/tournament/formats"
+)
+
+func TestSVG(t *testing.T) {
+	s := formats.NewSoloSingleElimState(t.Context(), "id", []string{"senez", "kha0x", "i77_", "<tauktes>"})
+
+	m := s.GetNextMatches()
+	s.HandleGameResult(t.Context(), m[0].ID, []string{m[0].Player1, m[0].Player2}, []uint64{120000, 135000})
+
+	bracket := s.GetBracket()
+	bracket.Rounds[0].Matches[0].Slots[0].Times = []uint64{120000}
+
+	svg := SVG(bracket)
+
+	if err := xml.Unmarshal([]byte(svg), new(struct{})); err != nil {
+		t.Fatalf("failed to parse svg: %v", err)
+	}
+
+	for _, expected := range []string{"(1) senez", "&lt;tauktes&gt;", "2:00.000", "Round 1", `font-weight="bold">(1) senez`} {
+		if !strings.Contains(svg, expected) {
+			t.Errorf("expected the svg to contain %q", expected)
+		}
+	}
+
+	if !strings.Contains(HTML(bracket, "title"), svg) {
+		t.Errorf("expected the html page to embed the svg")
+	}
+}
@@ -1,17 +1,21 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"time"
 	"tournament-manager/internal/server/handlers"
 
 	"github.com/gorilla/mux"
 )
 
-func StartServer() {
+// StartServer serves the API until ctx is cancelled, then stops accepting
+// connections and waits for the requests in flight to finish.
+func StartServer(ctx context.Context) error {
 	r := mux.NewRouter()
 	registerRoutes(r)
 
@@ -22,20 +26,92 @@ func StartServer() {
 	}
 	port = fmt.Sprintf(":%s", port)
 
-	// TODO: add some middleware to log requests and handle panics and stuff
-	slog.Error(http.ListenAndServe(port, r).Error())
+	srv := &http.Server{
+		Addr:              port,
+		Handler:           withMiddleware(r),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       durationFromEnv("HTTP_READ_TIMEOUT", 15*time.Second),
+		WriteTimeout:      durationFromEnv("HTTP_WRITE_TIMEOUT", 30*time.Second),
+		IdleTimeout:       durationFromEnv("HTTP_IDLE_TIMEOUT", 120*time.Second),
+	}
+	srv.RegisterOnShutdown(handlers.CloseStreams)
+
+	errs := make(chan error, 1)
+	go func() {
+		slog.Info("Starting server", "addr", port)
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return fmt.Errorf("server stopped: %w", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("Shutting down server")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout())
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+
+	slog.Info("Server stopped")
+	return nil
+}
+
+// ShutdownTimeout is how long shutdown waits for requests in flight, and
+// then for webhook deliveries, before giving up on them.
+func ShutdownTimeout() time.Duration {
+	return durationFromEnv("SHUTDOWN_TIMEOUT", 15*time.Second)
+}
+
+// durationFromEnv parses a duration such as "30s" from an environment
+// variable, falling back to def if it is unset or invalid.
+func durationFromEnv(name string, def time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		slog.Warn("Invalid duration in environment variable, using default", "name", name, "value", value, "default", def)
+		return def
+	}
+
+	return d
 }
 
 func registerRoutes(r *mux.Router) {
 	r.HandleFunc("/api/tournament", handlers.CreateTournament).Methods("POST")
 
+	r.HandleFunc("/api/tournament/{id}", handlers.GetTournament).Methods("GET")
+	r.HandleFunc("/api/tournament/{id}/seeds", handlers.SetSeeds).Methods("PUT")
+	r.HandleFunc("/api/tournament/{id}/checkin", handlers.CheckIn).Methods("POST")
 	r.HandleFunc("/api/tournament/{id}/start", handlers.StartTournament).Methods("POST")
 	r.HandleFunc("/api/tournament/{id}/result", handlers.SubmitGameResult).Methods("POST")
+	r.HandleFunc("/api/tournament/{id}/result/{game_id}", handlers.AmendGameResult).Methods("PUT")
+	r.HandleFunc("/api/tournament/{id}/result/{game_id}", handlers.VoidGameResult).Methods("DELETE")
+	r.HandleFunc("/api/tournament/{id}/players/{ign}/{action:forfeit|withdraw|disqualify}", handlers.Forfeit).Methods("POST")
 	r.HandleFunc("/api/tournament/{id}/status", handlers.GetTournamentStatus).Methods("GET")
 	r.HandleFunc("/api/tournament/{id}/matches", handlers.GetNextMatches).Methods("GET")
+	r.HandleFunc("/api/tournament/{id}/deadlines", handlers.GetMatchDeadlines).Methods("GET")
+	r.HandleFunc("/api/tournament/{id}/matches/{match_id}/report", handlers.ReportForMatch).Methods("POST")
+	r.HandleFunc("/api/tournament/{id}/matches/{match_id}/deadline", handlers.SetMatchDeadline).Methods("PUT")
 	r.HandleFunc("/api/tournament/{id}/bracket", handlers.GetTournamentBracket).Methods("GET")
+	r.HandleFunc("/api/tournament/{id}/bracket.svg", handlers.GetTournamentBracketSVG).Methods("GET")
+	r.HandleFunc("/api/tournament/{id}/bracket.html", handlers.GetTournamentBracketHTML).Methods("GET")
+	r.HandleFunc("/api/tournament/{id}/results", handlers.GetTournamentResults).Methods("GET")
+	r.HandleFunc("/api/tournament/{id}/events", handlers.TournamentEvents).Methods("GET")
+	r.HandleFunc("/api/tournament/{id}/webhooks", handlers.CreateWebhook).Methods("POST")
+	r.HandleFunc("/api/tournament/{id}/webhooks", handlers.ListWebhooks).Methods("GET")
+	r.HandleFunc("/api/tournament/{id}/webhooks/{webhook_id}", handlers.DeleteWebhook).Methods("DELETE")
+	r.HandleFunc("/api/tournament/{id}/webhooks/{webhook_id}/deliveries", handlers.ListWebhookDeliveries).Methods("GET")
 	r.HandleFunc("/api/tournament/{id}/stop", handlers.StopTournament).Methods("DELETE")
 
+	r.HandleFunc("/api/tournaments", handlers.ListTournaments).Methods("GET")
 	r.HandleFunc("/api/tournaments/active", handlers.ListActiveTournaments).Methods("GET")
 
 	r.HandleFunc("/api/signup", handlers.Signup).Methods("POST")
@@ -52,7 +128,7 @@ func authMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		username, password, ok := r.BasicAuth()
 		if !ok || username != os.Getenv("AUTH_USER") || password != os.Getenv("AUTH_PASSWORD") {
-			slog.Error("Attempted unauthorized access from %v (%v)", username, r.RemoteAddr)
+			slog.ErrorContext(r.Context(), "Attempted unauthorized access", "username", username, "remote_addr", r.RemoteAddr)
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"tournament-manager/internal/tournament"
+
+	"github.com/gorilla/mux"
+)
+
+type AmendGameResultRequest struct {
+	Players []string `json:"players"`
+	Times   []string `json:"times"`
+	Reason  string   `json:"reason"`
+	Cascade bool     `json:"cascade"`
+}
+
+// AmendGameResult replaces the players and times of a submitted game. Games
+// that depend on it are only voided along with it if cascade is set, the
+// request fails with 409 otherwise.
+func AmendGameResult(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+	gameID := vars["game_id"]
+
+	var req AmendGameResultRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if len(req.Players) == 0 {
+		http.Error(w, "players array cannot be empty", http.StatusBadRequest)
+		return
+	}
+
+	if len(req.Players) != len(req.Times) {
+		http.Error(w, "players and times arrays must have the same length", http.StatusBadRequest)
+		return
+	}
+
+	voided, err := tournament.Manager.AmendGameResult(r.Context(), tournamentID, gameID, req.Players, req.Times, req.Reason, req.Cascade)
+	if err != nil {
+		writeCorrectionError(w, r, tournamentID, gameID, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message":       "Game result amended successfully",
+		"tournament_id": tournamentID,
+		"game_id":       gameID,
+		"voided":        voided,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// VoidGameResult voids a submitted game. The reason and whether to void
+// dependent games too are passed as the reason and cascade query parameters.
+func VoidGameResult(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+	gameID := vars["game_id"]
+
+	query := r.URL.Query()
+	cascade := query.Get("cascade") == "true"
+
+	voided, err := tournament.Manager.VoidGameResult(r.Context(), tournamentID, gameID, query.Get("reason"), cascade)
+	if err != nil {
+		writeCorrectionError(w, r, tournamentID, gameID, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message":       "Game result voided successfully",
+		"tournament_id": tournamentID,
+		"game_id":       gameID,
+		"voided":        voided,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func writeCorrectionError(w http.ResponseWriter, r *http.Request, tournamentID, gameID string, err error) {
+	slog.WarnContext(r.Context(), "Failed to correct game result", "tournament_id", tournamentID, "game_id", gameID, "error", err)
+
+	if errors.Is(err, tournament.ErrDependentResults) {
+		http.Error(w, err.Error()+"; correct them first or retry with cascade to void them too", http.StatusConflict)
+		return
+	}
+
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
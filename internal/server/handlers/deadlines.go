@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+	"tournament-manager/internal/tournament"
+
+	"github.com/gorilla/mux"
+)
+
+// GetMatchDeadlines lists the deadlines of a tournament's pending matches.
+func GetMatchDeadlines(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+
+	deadlines, err := tournament.Manager.MatchDeadlines(r.Context(), tournamentID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to get match deadlines", "tournament_id", tournamentID, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	entries := make([]map[string]interface{}, len(deadlines))
+	for i, deadline := range deadlines {
+		entries[i] = map[string]interface{}{
+			"match_id":     deadline.MatchID,
+			"round":        deadline.Round,
+			"opened_at":    deadline.OpenedAt,
+			"deadline":     deadline.Deadline,
+			"overdue":      deadline.Overdue,
+			"auto_forfeit": deadline.AutoForfeit,
+			"reported":     deadline.Reported,
+		}
+	}
+
+	response := map[string]interface{}{
+		"tournament_id": tournamentID,
+		"deadlines":     entries,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ReportForMatch marks a player as ready for their pending match, which
+// keeps them from being forfeited when its deadline passes.
+func ReportForMatch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+	matchID := vars["match_id"]
+
+	var body struct {
+		Player string `json:"player"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := tournament.Manager.ReportForMatch(r.Context(), tournamentID, matchID, body.Player); err != nil {
+		slog.WarnContext(r.Context(), "Failed to report for match", "tournament_id", tournamentID, "match_id", matchID, "player", body.Player, "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message":       "Reported successfully",
+		"tournament_id": tournamentID,
+		"match_id":      matchID,
+		"ign":           body.Player,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+type SetMatchDeadlineRequest struct {
+	// Deadline is an RFC 3339 time. Extend, a duration such as "15m", moves
+	// the deadline on from now instead.
+	Deadline    string `json:"deadline"`
+	Extend      string `json:"extend"`
+	AutoForfeit *bool  `json:"auto_forfeit"`
+}
+
+// SetMatchDeadline lets an admin move the deadline of a pending match or
+// turn automatic forfeits off for it.
+func SetMatchDeadline(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+	matchID := vars["match_id"]
+
+	var req SetMatchDeadlineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var deadline time.Time
+	switch {
+	case req.Deadline != "" && req.Extend != "":
+		http.Error(w, "deadline and extend cannot both be set", http.StatusBadRequest)
+		return
+	case req.Deadline != "":
+		parsed, err := time.Parse(time.RFC3339, req.Deadline)
+		if err != nil {
+			http.Error(w, "deadline must be an RFC 3339 time", http.StatusBadRequest)
+			return
+		}
+		deadline = parsed
+	case req.Extend != "":
+		extend, err := time.ParseDuration(req.Extend)
+		if err != nil || extend <= 0 {
+			http.Error(w, "extend must be a positive duration", http.StatusBadRequest)
+			return
+		}
+		deadline = time.Now().Add(extend)
+	default:
+		http.Error(w, "deadline or extend is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := tournament.Manager.SetMatchDeadline(r.Context(), tournamentID, matchID, deadline, req.AutoForfeit); err != nil {
+		slog.WarnContext(r.Context(), "Failed to set match deadline", "tournament_id", tournamentID, "match_id", matchID, "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message":       "Match deadline updated successfully",
+		"tournament_id": tournamentID,
+		"match_id":      matchID,
+		"deadline":      deadline,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
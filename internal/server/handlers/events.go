@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+	"tournament-manager/internal/tournament"
+
+	"github.com/gorilla/mux"
+)
+
+// keepAliveInterval is how often an idle event stream gets a comment line so
+// proxies don't close the connection.
+const keepAliveInterval = 30 * time.Second
+
+// streamsClosed is closed when the server shuts down, so open event streams
+// end instead of holding up the shutdown.
+var streamsClosed = make(chan struct{})
+
+var closeStreams sync.Once
+
+// CloseStreams ends every open event stream.
+func CloseStreams() {
+	closeStreams.Do(func() { close(streamsClosed) })
+}
+
+// TournamentEvents streams the events of a tournament as Server-Sent Events
+// until the client disconnects.
+func TournamentEvents(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+
+	if tournamentID == "" {
+		http.Error(w, "tournament ID is required", http.StatusBadRequest)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+
+	// The stream stays open far longer than the server's write timeout.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		slog.DebugContext(r.Context(), "Failed to clear write deadline", "tournament_id", tournamentID, "error", err)
+	}
+
+	events, unsubscribe := tournament.Manager.Subscribe(tournamentID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprint(w, ": connected\n\n")
+	if err := rc.Flush(); err != nil {
+		slog.WarnContext(r.Context(), "Event stream not supported", "tournament_id", tournamentID, "error", err)
+		return
+	}
+
+	slog.DebugContext(r.Context(), "Event stream opened", "tournament_id", tournamentID, "remote_addr", r.RemoteAddr)
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-streamsClosed:
+			return
+		case <-r.Context().Done():
+			slog.DebugContext(r.Context(), "Event stream closed", "tournament_id", tournamentID, "remote_addr", r.RemoteAddr)
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				slog.ErrorContext(r.Context(), "Failed to encode event", "tournament_id", tournamentID, "type", event.Type, "error", err)
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"tournament-manager/internal/tournament"
+
+	"github.com/gorilla/mux"
+)
+
+type ForfeitRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Forfeit takes a player out of a tournament with the forfeit, withdraw or
+// disqualify action given in the path. The request body is optional.
+func Forfeit(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+	ign := vars["ign"]
+	action := vars["action"]
+
+	var req ForfeitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := tournament.Manager.Forfeit(r.Context(), tournamentID, ign, action, req.Reason); err != nil {
+		slog.WarnContext(r.Context(), "Failed to forfeit player", "tournament_id", tournamentID, "ign", ign, "action", action, "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message":       "Player removed successfully",
+		"tournament_id": tournamentID,
+		"ign":           ign,
+		"action":        action,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
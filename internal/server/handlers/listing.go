@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+	"tournament-manager/internal/tournament"
+
+	"github.com/gorilla/mux"
+)
+
+// ListTournaments lists tournaments from the database, latest first. It
+// accepts the query parameters status (upcoming, in_progress, completed or
+// stopped), format, from and to (RFC 3339 times or YYYY-MM-DD dates),
+// order=asc, limit and offset.
+func ListTournaments(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter := tournament.TournamentFilter{
+		Status:    query.Get("status"),
+		Format:    query.Get("format"),
+		Ascending: query.Get("order") == "asc",
+	}
+
+	var err error
+	if filter.From, err = parseDateParam(query.Get("from"), false); err != nil {
+		http.Error(w, fmt.Sprintf("invalid from: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if filter.To, err = parseDateParam(query.Get("to"), true); err != nil {
+		http.Error(w, fmt.Sprintf("invalid to: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	for name, target := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			http.Error(w, fmt.Sprintf("%s must be a non-negative integer", name), http.StatusBadRequest)
+			return
+		}
+		*target = n
+	}
+
+	page, err := tournament.Manager.ListTournaments(r.Context(), filter)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Failed to list tournaments", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tournaments := make([]map[string]interface{}, len(page.Tournaments))
+	for i, summary := range page.Tournaments {
+		tournaments[i] = map[string]interface{}{
+			"id":           summary.ID,
+			"name":         summary.Name,
+			"date":         time.Unix(int64(summary.Date), 0).UTC(),
+			"format":       summary.Format,
+			"status":       summary.Status,
+			"player_count": summary.Players,
+			"winner":       summary.Winner,
+			"completed_at": summary.CompletedAt,
+		}
+	}
+
+	response := map[string]interface{}{
+		"tournaments": tournaments,
+		"total":       page.Total,
+		"limit":       page.Limit,
+		"offset":      page.Offset,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// parseDateParam parses an RFC 3339 time or a YYYY-MM-DD date, which stands
+// for the end of that day if endOfDay is set and for its start otherwise.
+func parseDateParam(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, fmt.Errorf("expected an RFC 3339 time or a YYYY-MM-DD date, got %q", value)
+	}
+
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Second)
+	}
+	return &t, nil
+}
+
+// GetTournament returns a tournament's details and players, whether it is
+// upcoming, in progress or over.
+func GetTournament(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+
+	details, err := tournament.Manager.GetTournament(r.Context(), tournamentID)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Failed to get tournament", "tournament_id", tournamentID, "error", err)
+		if errors.Is(err, tournament.ErrTournamentNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	players := make([]map[string]interface{}, len(details.Participants))
+	for i, player := range details.Participants {
+		players[i] = map[string]interface{}{
+			"ign":           player.IGN,
+			"discord_name":  player.DiscordName,
+			"personal_best": player.PersonalBest,
+			"seed":          player.Seed,
+			"status":        cmp.Or(player.Status, tournament.PlayerRegistered),
+			"checked_in":    player.CheckedIn,
+		}
+	}
+
+	response := map[string]interface{}{
+		"id":           details.ID,
+		"name":         details.Name,
+		"date":         time.Unix(int64(details.Date), 0).UTC(),
+		"format":       details.Format,
+		"options":      details.Options,
+		"status":       details.Status,
+		"active":       details.Active,
+		"seeding":      details.Seeding,
+		"winner":       details.Winner,
+		"completed_at": details.CompletedAt,
+		"player_count": details.Players,
+		"players":      players,
+	}
+
+	if details.CheckIn != nil {
+		opens, closes := details.CheckIn.Window(details.Date)
+		response["check_in"] = map[string]interface{}{
+			"opens":             opens.UTC(),
+			"closes":            closes.UTC(),
+			"start_when_closed": details.CheckIn.StartWhenClosed,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
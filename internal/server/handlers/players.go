@@ -6,11 +6,13 @@ import (
 	"net/http"
 	"tournament-manager/internal/tournament"
 	"tournament-manager/internal/util"
+
+	"github.com/gorilla/mux"
 )
 
 func Signup(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseForm(); err != nil {
-		slog.Error(err.Error())
+		slog.ErrorContext(r.Context(), err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -23,21 +25,101 @@ func Signup(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		slog.Error(err.Error())
+		slog.ErrorContext(r.Context(), err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	pb, err := util.ParseTime(body.PersonalBest)
 	if err != nil {
-		slog.Error(err.Error())
+		slog.ErrorContext(r.Context(), err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := tournament.Manager.Signup(r.Context(), body.Ign, body.DiscordName, pb, body.TournamentID); err != nil {
+		slog.ErrorContext(r.Context(), err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+}
+
+// CheckIn checks a player in by IGN or Discord name.
+func CheckIn(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+
+	var body struct {
+		Player string `json:"player"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if body.Player == "" {
+		http.Error(w, "player is required", http.StatusBadRequest)
+		return
+	}
 
-	if err := tournament.Signup(body.Ign, body.DiscordName, pb, body.TournamentID); err != nil {
-		slog.Error(err.Error())
+	ign, err := tournament.Manager.CheckIn(r.Context(), tournamentID, body.Player)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Failed to check in player", "tournament_id", tournamentID, "player", body.Player, "error", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+
+	response := map[string]interface{}{
+		"message":       "Checked in successfully",
+		"tournament_id": tournamentID,
+		"ign":           ign,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func SetSeeds(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+
+	if tournamentID == "" {
+		http.Error(w, "tournament ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var body struct {
+		Seeds []string `json:"seeds"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		slog.ErrorContext(r.Context(), err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, ign := range body.Seeds {
+		if seen[ign] {
+			http.Error(w, "player "+ign+" is seeded more than once", http.StatusBadRequest)
+			return
+		}
+		seen[ign] = true
+	}
+
+	if err := tournament.Manager.SetSeeds(r.Context(), tournamentID, body.Seeds); err != nil {
+		slog.WarnContext(r.Context(), "Failed to set seeds", "tournament_id", tournamentID, "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message":       "Seeds updated successfully",
+		"tournament_id": tournamentID,
+		"seeds":         body.Seeds,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
 }
@@ -8,66 +8,92 @@ import (
 	"net/http"
 	"time"
 	"tournament-manager/internal/tournament"
+	"tournament-manager/internal/tournament/formats"
 )
 
 func CreateTournament(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseForm(); err != nil {
-		slog.Error(err.Error())
+		slog.ErrorContext(r.Context(), err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	var body struct {
-		Name   string `json:"name"`
-		Time   string `json:"time"`
-		Format string `json:"format"`
+		Name    string          `json:"name"`
+		Time    string          `json:"time"`
+		Format  string          `json:"format"`
+		Options formats.Options `json:"options"`
+		CheckIn *struct {
+			Opens           string `json:"opens"`
+			Closes          string `json:"closes"`
+			StartWhenClosed bool   `json:"start_when_closed"`
+		} `json:"check_in"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		slog.Error(err.Error())
+		slog.ErrorContext(r.Context(), err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	ts, err := time.Parse("2006-01-02 15:04 MST", body.Time)
 	if err != nil {
-		slog.Error(err.Error())
+		slog.ErrorContext(r.Context(), err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	tsUint := uint64(ts.Unix())
 
-	if err := validateCreateTournament(body.Name, tsUint, body.Format); err != nil {
-		slog.Error(err.Error())
+	var checkIn *tournament.CheckIn
+	if body.CheckIn != nil {
+		opens, err := time.ParseDuration(body.CheckIn.Opens)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid check-in opening time: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		closes, err := time.ParseDuration(body.CheckIn.Closes)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid check-in closing time: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		checkIn = &tournament.CheckIn{Opens: opens, Closes: closes, StartWhenClosed: body.CheckIn.StartWhenClosed}
+	}
+
+	if err := validateCreateTournament(body.Name, tsUint, body.Format, body.Options, checkIn); err != nil {
+		slog.ErrorContext(r.Context(), err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	id, err := tournament.CreateTournament(body.Name, tsUint, body.Format)
+	id, err := tournament.Manager.CreateTournament(r.Context(), body.Name, tsUint, body.Format, body.Options, checkIn)
 	if err != nil {
-		slog.Error(err.Error())
+		slog.ErrorContext(r.Context(), err.Error())
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	response := map[string]interface{}{
-		"message": "Tournament created successfully",
-		"name":    body.Name,
-		"date":    body.Time,
-		"format":  body.Format,
-		"id":      id,
+		"message":  "Tournament created successfully",
+		"name":     body.Name,
+		"date":     body.Time,
+		"format":   body.Format,
+		"options":  body.Options,
+		"check_in": body.CheckIn,
+		"id":       id,
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		slog.Error(err.Error())
+		slog.ErrorContext(r.Context(), err.Error())
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 }
 
-func validateCreateTournament(name string, ts uint64, format string) error {
-	var err1, err2, err3 error
+func validateCreateTournament(name string, ts uint64, format string, opts formats.Options, checkIn *tournament.CheckIn) error {
+	var err1, err2, err3, err4, err5 error
 	if name == "" {
 		err1 = fmt.Errorf("tournament name cannot be empty")
 	}
@@ -80,7 +106,15 @@ func validateCreateTournament(name string, ts uint64, format string) error {
 		err3 = fmt.Errorf("unknown tournament format: %v", format)
 	}
 
-	if err1 == nil && err2 == nil && err3 == nil {
+	if err := opts.Validate(); err != nil {
+		err4 = err
+	}
+
+	if checkIn != nil {
+		err5 = checkIn.Validate()
+	}
+
+	if err1 == nil && err2 == nil && err3 == nil && err4 == nil && err5 == nil {
 		return nil
 	}
 
@@ -96,5 +130,13 @@ func validateCreateTournament(name string, ts uint64, format string) error {
 	if err3 != nil {
 		err = fmt.Errorf("%v: %v", err, err3)
 	}
+
+	if err4 != nil {
+		err = fmt.Errorf("%v: %v", err, err4)
+	}
+
+	if err5 != nil {
+		err = fmt.Errorf("%v: %v", err, err5)
+	}
 	return err
 }
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"tournament-manager/internal/render"
 	"tournament-manager/internal/tournament"
 
 	"github.com/gorilla/mux"
@@ -28,9 +30,9 @@ func StartTournament(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := tournament.Manager.StartTournament(tournamentID)
+	dropped, err := tournament.Manager.StartTournament(r.Context(), tournamentID)
 	if err != nil {
-		slog.Warn("Failed to start tournament", "tournament_id", tournamentID, "error", err)
+		slog.WarnContext(r.Context(), "Failed to start tournament", "tournament_id", tournamentID, "error", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -38,6 +40,7 @@ func StartTournament(w http.ResponseWriter, r *http.Request) {
 	response := map[string]interface{}{
 		"message":       "Tournament started successfully",
 		"tournament_id": tournamentID,
+		"dropped":       dropped,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -74,13 +77,25 @@ func SubmitGameResult(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := tournament.Manager.HandleGameResult(tournamentID, req.GameID, req.Players, req.Times)
+	// Clients retrying a timed out request send the same Idempotency-Key, and
+	// a repeat of an earlier submission gets the response of the original.
+	key := r.Header.Get("Idempotency-Key")
+	replayed, err := tournament.Manager.SubmitGameResult(r.Context(), tournamentID, key, req.GameID, req.Players, req.Times)
+	if errors.Is(err, tournament.ErrConflictingResult) {
+		slog.WarnContext(r.Context(), "Rejected conflicting game result", "tournament_id", tournamentID, "game_id", req.GameID, "key", key)
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
 	if err != nil {
-		slog.Warn("Failed to handle game result", "tournament_id", tournamentID, "game_id", req.GameID, "error", err)
+		slog.WarnContext(r.Context(), "Failed to handle game result", "tournament_id", tournamentID, "game_id", req.GameID, "error", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	if replayed {
+		w.Header().Set("Idempotent-Replayed", "true")
+	}
+
 	response := map[string]interface{}{
 		"message":       "Game result processed successfully",
 		"tournament_id": tournamentID,
@@ -102,7 +117,7 @@ func GetTournamentStatus(w http.ResponseWriter, r *http.Request) {
 
 	status, err := tournament.Manager.GetTournamentStatus(tournamentID)
 	if err != nil {
-		slog.Warn("Failed to get tournament status", "tournament_id", tournamentID, "error", err)
+		slog.WarnContext(r.Context(), "Failed to get tournament status", "tournament_id", tournamentID, "error", err)
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
@@ -122,7 +137,7 @@ func GetNextMatches(w http.ResponseWriter, r *http.Request) {
 
 	matches, err := tournament.Manager.GetNextMatches(tournamentID)
 	if err != nil {
-		slog.Warn("Failed to get next matches", "tournament_id", tournamentID, "error", err)
+		slog.WarnContext(r.Context(), "Failed to get next matches", "tournament_id", tournamentID, "error", err)
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
@@ -145,9 +160,22 @@ func GetTournamentBracket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if r.URL.Query().Get("format") == "json" {
+		bracket, err := tournament.Manager.GetBracketData(r.Context(), tournamentID)
+		if err != nil {
+			slog.WarnContext(r.Context(), "Failed to get tournament bracket", "tournament_id", tournamentID, "error", err)
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(bracket)
+		return
+	}
+
 	bracket, err := tournament.Manager.GetBracket(tournamentID)
 	if err != nil {
-		slog.Warn("Failed to get tournament bracket", "tournament_id", tournamentID, "error", err)
+		slog.WarnContext(r.Context(), "Failed to get tournament bracket", "tournament_id", tournamentID, "error", err)
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
@@ -161,6 +189,36 @@ func GetTournamentBracket(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+func GetTournamentBracketSVG(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+
+	bracket, err := tournament.Manager.GetBracketData(r.Context(), tournamentID)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Failed to get tournament bracket", "tournament_id", tournamentID, "error", err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Write([]byte(render.SVG(bracket)))
+}
+
+func GetTournamentBracketHTML(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+
+	bracket, err := tournament.Manager.GetBracketData(r.Context(), tournamentID)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Failed to get tournament bracket", "tournament_id", tournamentID, "error", err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(render.HTML(bracket, "Tournament "+tournamentID)))
+}
+
 func StopTournament(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	tournamentID := vars["id"]
@@ -170,9 +228,9 @@ func StopTournament(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := tournament.Manager.StopTournament(tournamentID)
+	err := tournament.Manager.StopTournament(r.Context(), tournamentID)
 	if err != nil {
-		slog.Warn("Failed to stop tournament", "tournament_id", tournamentID, "error", err)
+		slog.WarnContext(r.Context(), "Failed to stop tournament", "tournament_id", tournamentID, "error", err)
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
@@ -197,3 +255,23 @@ func ListActiveTournaments(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+func GetTournamentResults(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+
+	if tournamentID == "" {
+		http.Error(w, "tournament ID is required", http.StatusBadRequest)
+		return
+	}
+
+	results, err := tournament.Manager.GetTournamentResults(r.Context(), tournamentID)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Failed to get tournament results", "tournament_id", tournamentID, "error", err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"tournament-manager/internal/webhooks"
+
+	"github.com/gorilla/mux"
+)
+
+func CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+
+	if tournamentID == "" {
+		http.Error(w, "tournament ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var body struct {
+		URL    string   `json:"url"`
+		Events []string `json:"events"`
+		Secret string   `json:"secret"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		slog.ErrorContext(r.Context(), err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := webhooks.Create(tournamentID, body.URL, body.Events, body.Secret)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Failed to create webhook", "tournament_id", tournamentID, "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(webhook)
+}
+
+func ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+
+	if tournamentID == "" {
+		http.Error(w, "tournament ID is required", http.StatusBadRequest)
+		return
+	}
+
+	list, err := webhooks.List(tournamentID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to list webhooks", "tournament_id", tournamentID, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"tournament_id": tournamentID,
+		"webhooks":      list,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+	webhookID := vars["webhook_id"]
+
+	if err := webhooks.Delete(tournamentID, webhookID); err != nil {
+		slog.WarnContext(r.Context(), "Failed to delete webhook", "tournament_id", tournamentID, "webhook_id", webhookID, "error", err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	response := map[string]interface{}{
+		"message":    "Webhook deleted successfully",
+		"webhook_id": webhookID,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tournamentID := vars["id"]
+	webhookID := vars["webhook_id"]
+
+	deliveries, err := webhooks.Deliveries(tournamentID, webhookID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Failed to list webhook deliveries", "tournament_id", tournamentID, "webhook_id", webhookID, "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"webhook_id": webhookID,
+		"deliveries": deliveries,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"tournament-manager/internal/logging"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	handler := withMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var players []string
+		_ = players[0]
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/api/test", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("unexpected status, expected %v, got %v", http.StatusInternalServerError, rec.Code)
+	}
+
+	var body map[string]string
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if body["request_id"] == "" || body["request_id"] != rec.Header().Get(requestIDHeader) {
+		t.Errorf("unexpected request ID, expected %v, got %v", rec.Header().Get(requestIDHeader), body["request_id"])
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var got string
+	handler := withMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = logging.RequestID(r.Context())
+	}))
+
+	req := httptest.NewRequest("GET", "/api/test", nil)
+	req.Header.Set(requestIDHeader, "abc123")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if got != "abc123" || rec.Header().Get(requestIDHeader) != "abc123" {
+		t.Errorf("unexpected request ID, expected %v, got %v", "abc123", got)
+	}
+}
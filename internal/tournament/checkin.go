@@ -0,0 +1,108 @@
+package tournament
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// CheckIn is the window before a tournament's start in which players confirm
+// they will play. Both times are relative to the tournament's date, so
+// {Opens: time.Hour, Closes: 10 * time.Minute} opens check-in an hour before
+// the start and closes it ten minutes before.
+type CheckIn struct {
+	Opens  time.Duration
+	Closes time.Duration
+	// StartWhenClosed has the scheduler start the tournament as soon as
+	// check-in closes instead of at its date.
+	StartWhenClosed bool
+}
+
+func (c CheckIn) Validate() error {
+	if c.Closes < 0 {
+		return fmt.Errorf("check-in cannot close after the tournament starts")
+	}
+
+	if c.Opens <= c.Closes {
+		return fmt.Errorf("check-in must open before it closes")
+	}
+
+	if c.Opens%time.Second != 0 || c.Closes%time.Second != 0 {
+		return fmt.Errorf("check-in times must be whole seconds")
+	}
+
+	return nil
+}
+
+// Window returns when check-in opens and closes for a tournament starting at
+// date, in Unix seconds.
+func (c CheckIn) Window(date uint64) (time.Time, time.Time) {
+	start := time.Unix(int64(date), 0)
+	return start.Add(-c.Opens), start.Add(-c.Closes)
+}
+
+// CheckIn checks a player in by IGN or Discord name, returning their IGN.
+// Players can only check in while the tournament's check-in window is open.
+func (tm *TournamentManager) CheckIn(ctx context.Context, tournamentID, name string) (string, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	tournament, err := tm.tournaments.Get(ctx, tournamentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get tournament from database: %w", err)
+	}
+
+	if tournament.CheckIn == nil {
+		return "", fmt.Errorf("tournament %s has no check-in", tournamentID)
+	}
+
+	if _, active := tm.activeTournaments[tournamentID]; active || tournament.Status != StatusScheduled {
+		return "", fmt.Errorf("tournament %s has already started", tournamentID)
+	}
+
+	opens, closes := tournament.CheckIn.Window(tournament.Date)
+	now := time.Now()
+	if now.Before(opens) {
+		return "", fmt.Errorf("check-in opens at %s", opens.UTC().Format(time.RFC3339))
+	}
+
+	if !now.Before(closes) {
+		return "", fmt.Errorf("check-in closed at %s", closes.UTC().Format(time.RFC3339))
+	}
+
+	ign, err := tm.players.CheckIn(ctx, tournamentID, name)
+	if err != nil {
+		slog.WarnContext(ctx, err.Error())
+		return "", err
+	}
+
+	slog.InfoContext(ctx, "Player checked in", "tournament_id", tournamentID, "ign", ign)
+
+	tm.publish(tournamentID, EventPlayerCheckedIn, map[string]interface{}{
+		"player": ign,
+	})
+
+	return ign, nil
+}
+
+// checkedInPlayers splits a seeding into the players who checked in and the
+// ones who didn't, keeping the seed order of both.
+func (tm *TournamentManager) checkedInPlayers(ctx context.Context, tournamentID string, seeding []string) ([]string, []string, error) {
+	checkedIn, err := tm.players.CheckedIn(ctx, tournamentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get checked in players: %w", err)
+	}
+
+	var players, dropped []string
+	for _, ign := range seeding {
+		if slices.Contains(checkedIn, ign) {
+			players = append(players, ign)
+		} else {
+			dropped = append(dropped, ign)
+		}
+	}
+
+	return players, dropped, nil
+}
@@ -0,0 +1,80 @@
+package tournament_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+	"tournament-manager/internal/tournament"
+	"tournament-manager/internal/tournament/formats"
+)
+
+// newCheckInTournament creates a single elimination tournament with four
+// players starting in the given time, with check-in open from an hour to ten
+// minutes before the start.
+func newCheckInTournament(t *testing.T, in time.Duration) (*tournament.TournamentManager, string) {
+	t.Helper()
+
+	tm := tournament.NewTournamentManager(tournament.NewMemoryRepositories())
+
+	checkIn := &tournament.CheckIn{Opens: time.Hour, Closes: 10 * time.Minute}
+	date := uint64(time.Now().Add(in).Unix())
+	id, err := tm.CreateTournament(t.Context(), "test", date, "solo_single_elim", formats.Options{}, checkIn)
+	if err != nil {
+		t.Fatalf("failed to create tournament: %v", err)
+	}
+
+	for i, ign := range []string{"senez", "kha0x", "i77_", "tauktes"} {
+		if err := tm.Signup(t.Context(), ign, ign+"#0001", uint64(120000+i*1000), id); err != nil {
+			t.Fatalf("failed to sign up %v: %v", ign, err)
+		}
+	}
+
+	return tm, id
+}
+
+func TestStartWithCheckedInPlayers(t *testing.T) {
+	tm, id := newCheckInTournament(t, 30*time.Minute)
+
+	if _, err := tm.CheckIn(t.Context(), id, "senez"); err != nil {
+		t.Fatalf("failed to check in by IGN: %v", err)
+	}
+
+	ign, err := tm.CheckIn(t.Context(), id, "i77_#0001")
+	if err != nil {
+		t.Fatalf("failed to check in by Discord name: %v", err)
+	}
+
+	if ign != "i77_" {
+		t.Errorf("unexpected IGN, expected %v, got %v", "i77_", ign)
+	}
+
+	if _, err := tm.CheckIn(t.Context(), id, "nobody"); err == nil {
+		t.Errorf("expected checking in an unknown player to fail")
+	}
+
+	dropped, err := tm.StartTournament(t.Context(), id)
+	if err != nil {
+		t.Fatalf("failed to start tournament: %v", err)
+	}
+
+	if expected := []string{"kha0x", "tauktes"}; !slices.Equal(dropped, expected) {
+		t.Errorf("unexpected dropped players, expected %v, got %v", expected, dropped)
+	}
+
+	m, _ := tm.GetNextMatches(id)
+	if len(m) != 1 || !slices.Equal(m[0].Participants(), []string{"senez", "i77_"}) {
+		t.Errorf("unexpected matches, expected senez against i77_, got %v", m)
+	}
+}
+
+func TestCheckInWindow(t *testing.T) {
+	tm, id := newCheckInTournament(t, 2*time.Hour)
+	if _, err := tm.CheckIn(t.Context(), id, "senez"); err == nil {
+		t.Errorf("expected check-in before the window opens to fail")
+	}
+
+	tm, id = newCheckInTournament(t, 5*time.Minute)
+	if _, err := tm.CheckIn(t.Context(), id, "senez"); err == nil {
+		t.Errorf("expected check-in after the window closes to fail")
+	}
+}
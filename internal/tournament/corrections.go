@@ -0,0 +1,230 @@
+package tournament
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"tournament-manager/internal/tournament/formats"
+	"tournament-manager/internal/util"
+)
+
+const (
+	CorrectionAmend = "amend"
+	CorrectionVoid  = "void"
+)
+
+// Correction is a change to an earlier submission. Corrections are kept as
+// an audit trail next to the submissions they change.
+type Correction struct {
+	Action string
+	Reason string
+	Before Submission
+	// After holds the amended submission, or nil if it was voided.
+	After *Submission
+}
+
+// ErrDependentResults is returned for a correction that later games depend
+// on, unless those games are voided along with it.
+var ErrDependentResults = errors.New("later games depend on this result")
+
+// AmendGameResult replaces the players and times of the latest submission of
+// a game and replays the tournament from its seeding. Later games that no
+// longer fit the corrected bracket are voided if cascade is set; otherwise
+// the amendment is refused with ErrDependentResults. It returns the IDs of
+// the games voided along with it.
+func (tm *TournamentManager) AmendGameResult(ctx context.Context, tournamentID, gameID string, players []string, timesStr []string, reason string, cascade bool) ([]string, error) {
+	if len(players) == 0 || len(players) != len(timesStr) {
+		return nil, fmt.Errorf("expected a time for each of the %d players, got %d", len(players), len(timesStr))
+	}
+
+	times := make([]uint64, len(timesStr))
+	for i, timeStr := range timesStr {
+		time, err := util.ParseTime(timeStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse time for player %s: %w", players[i], err)
+		}
+		times[i] = time
+	}
+
+	return tm.correct(ctx, tournamentID, gameID, reason, cascade, func(before Submission) *Submission {
+		after := before
+		after.Players = players
+		after.Times = times
+		return &after
+	})
+}
+
+// VoidGameResult voids the latest submission of a game and replays the
+// tournament from its seeding, treating dependent games like
+// AmendGameResult does.
+func (tm *TournamentManager) VoidGameResult(ctx context.Context, tournamentID, gameID string, reason string, cascade bool) ([]string, error) {
+	return tm.correct(ctx, tournamentID, gameID, reason, cascade, func(Submission) *Submission {
+		return nil
+	})
+}
+
+// correct replaces the latest submission of a game with the one returned by
+// change, or drops it if that is nil, and saves the replayed tournament.
+func (tm *TournamentManager) correct(ctx context.Context, tournamentID, gameID, reason string, cascade bool, change func(Submission) *Submission) ([]string, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	tournament, err := tm.tournaments.Get(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tournament from database: %w", err)
+	}
+
+	previous, active := tm.activeTournaments[tournamentID]
+	if !active && tournament.Status != StatusCompleted {
+		return nil, fmt.Errorf("tournament %s is neither active nor completed", tournamentID)
+	}
+
+	submissions, err := tm.results.Submissions(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := -1
+	for i, submission := range submissions {
+		if submission.Kind == "" && submission.GameID == gameID {
+			target = i
+		}
+	}
+
+	if target == -1 {
+		return nil, fmt.Errorf("no result has been submitted for game %s", gameID)
+	}
+
+	action := CorrectionVoid
+	corrected := slices.Clone(submissions)
+	after := change(submissions[target])
+	if after != nil {
+		action = CorrectionAmend
+		corrected[target] = *after
+	} else {
+		corrected = slices.Delete(corrected, target, target+1)
+	}
+
+	seeding := tournament.Seeding
+	if len(seeding) == 0 {
+		if seeding, err = tm.getPlayersForTournament(ctx, tournamentID); err != nil {
+			return nil, fmt.Errorf("failed to get players for tournament: %w", err)
+		}
+	}
+
+	state, results, dropped, err := replay(ctx, tournament.Format, tournamentID, seeding, tournament.Options, corrected)
+	if err != nil {
+		return nil, err
+	}
+
+	// The corrected game itself has to fit the bracket; only later games may
+	// be voided because of it.
+	if after != nil && slices.ContainsFunc(dropped, func(s Submission) bool { return s.ID == after.ID }) {
+		return nil, fmt.Errorf("amended result for game %s doesn't fit the bracket", gameID)
+	}
+
+	voided := make([]string, len(dropped))
+	for i, submission := range dropped {
+		voided[i] = submissionLabel(submission)
+	}
+
+	if len(dropped) > 0 && !cascade {
+		return nil, fmt.Errorf("%w: %s", ErrDependentResults, strings.Join(voided, ", "))
+	}
+
+	corrections := []Correction{{Action: action, Reason: reason, Before: submissions[target], After: after}}
+	for _, submission := range dropped {
+		corrections = append(corrections, Correction{
+			Action: CorrectionVoid,
+			Reason: fmt.Sprintf("depends on corrected game %s", gameID),
+			Before: submission,
+		})
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tournament state: %w", err)
+	}
+
+	var completed *TournamentResults
+	if state.IsFinished() {
+		completed = finalResults(tournamentID, state)
+	}
+
+	if err := tm.results.Correct(ctx, tournamentID, corrections, results, data, completed); err != nil {
+		slog.ErrorContext(ctx, "Failed to correct game result", "tournament_id", tournamentID, "game_id", gameID, "error", err)
+		return nil, fmt.Errorf("failed to correct game result: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Game result corrected", "tournament_id", tournamentID, "game_id", gameID, "action", action, "voided", voided, "reason", reason)
+
+	if completed == nil {
+		tm.activeTournaments[tournamentID] = state
+	} else {
+		delete(tm.activeTournaments, tournamentID)
+	}
+	tm.syncDeadlines(ctx, tournamentID, state, time.Now())
+
+	var pending []formats.Match
+	if active {
+		pending = previous.GetNextMatches()
+	}
+
+	tm.publish(tournamentID, EventResultCorrected, map[string]interface{}{
+		"game_id": gameID,
+		"action":  action,
+		"voided":  voided,
+		"reason":  reason,
+	})
+	tm.publishMatchChanges(tournamentID, pending, state.GetNextMatches())
+
+	if completed != nil {
+		tm.publish(tournamentID, EventTournamentCompleted, map[string]interface{}{
+			"winner":     completed.Winner,
+			"placements": completed.Placements,
+		})
+	}
+
+	return voided, nil
+}
+
+// replay starts a tournament over from its seeding and applies the
+// submissions in order. Submissions the bracket no longer accepts are left
+// out and returned as dropped, along with the state and the game results of
+// the ones that applied.
+func replay(ctx context.Context, format, tournamentID string, seeding []string, opts formats.Options, submissions []Submission) (formats.Format, []formats.GameResult, []Submission, error) {
+	state, err := formats.New(ctx, format, tournamentID, seeding, opts)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create tournament state: %w", err)
+	}
+
+	var results []formats.GameResult
+	var dropped []Submission
+	for _, submission := range submissions {
+		if state.IsFinished() {
+			dropped = append(dropped, submission)
+			continue
+		}
+
+		next, err := formats.Clone(state)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to copy tournament state: %w", err)
+		}
+
+		if err := applySubmission(ctx, next, submission); err != nil {
+			slog.DebugContext(ctx, "Dropping submission on replay", "tournament_id", tournamentID, "submission", submissionLabel(submission), "error", err)
+			dropped = append(dropped, submission)
+			continue
+		}
+
+		state = next
+		results = append(results, rankResults(submission)...)
+	}
+
+	return state, results, dropped, nil
+}
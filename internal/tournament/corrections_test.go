@@ -0,0 +1,98 @@
+package tournament_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"tournament-manager/internal/tournament"
+	"tournament-manager/internal/tournament/formats"
+)
+
+// play submits a result for the first pending match, won by its first
+// player, and returns the match.
+func play(t *testing.T, tm *tournament.TournamentManager, id string) formats.Match {
+	t.Helper()
+
+	m, _ := tm.GetNextMatches(id)
+	if len(m) == 0 {
+		t.Fatalf("expected a pending match")
+	}
+
+	if err := tm.HandleGameResult(t.Context(), id, m[0].ID, []string{m[0].Player1, m[0].Player2}, []string{"2:00.0", "2:15.0"}); err != nil {
+		t.Fatalf("failed to submit result for %v: %v", m[0].ID, err)
+	}
+
+	return m[0]
+}
+
+func TestVoidGameResult(t *testing.T) {
+	tm, repos, id := newTournament(t)
+
+	first := play(t, tm, id)
+
+	voided, err := tm.VoidGameResult(t.Context(), id, first.ID, "wrong lobby", false)
+	if err != nil {
+		t.Fatalf("failed to void result: %v", err)
+	}
+
+	if len(voided) != 0 {
+		t.Errorf("unexpected voided games, expected none, got %v", voided)
+	}
+
+	m, _ := tm.GetNextMatches(id)
+	if !slices.ContainsFunc(m, func(match formats.Match) bool { return match.ID == first.ID }) {
+		t.Errorf("expected match %v to be pending again, got %v", first.ID, m)
+	}
+
+	saved, _ := repos.Results.GameResults(t.Context(), id)
+	if len(saved) != 0 {
+		t.Errorf("unexpected number of stored game results, expected 0, got %d", len(saved))
+	}
+}
+
+func TestAmendGameResultWithDependents(t *testing.T) {
+	tm, _, id := newTournament(t)
+
+	first := play(t, tm, id)
+	play(t, tm, id)
+	final := play(t, tm, id)
+
+	swapped := []string{first.Player1, first.Player2}
+	times := []string{"2:30.0", "2:15.0"}
+
+	if _, err := tm.AmendGameResult(t.Context(), id, first.ID, swapped, times, "times swapped", false); !errors.Is(err, tournament.ErrDependentResults) {
+		t.Fatalf("unexpected error, expected %v, got %v", tournament.ErrDependentResults, err)
+	}
+
+	if _, err := tm.GetTournamentResults(t.Context(), id); err != nil {
+		t.Errorf("expected the tournament to stay completed after a refused correction, got %v", err)
+	}
+
+	voided, err := tm.AmendGameResult(t.Context(), id, first.ID, swapped, times, "times swapped", true)
+	if err != nil {
+		t.Fatalf("failed to amend result: %v", err)
+	}
+
+	if !slices.Equal(voided, []string{final.ID}) {
+		t.Errorf("unexpected voided games, expected %v, got %v", []string{final.ID}, voided)
+	}
+
+	if !tm.IsActive(id) {
+		t.Fatalf("expected the tournament to be reopened")
+	}
+
+	m, _ := tm.GetNextMatches(id)
+	if len(m) != 1 || !slices.Contains(m[0].Participants(), first.Player2) {
+		t.Errorf("unexpected pending matches, expected a final with %v, got %v", first.Player2, m)
+	}
+}
+
+func TestAmendGameResultRejectsMismatchedPlayers(t *testing.T) {
+	tm, _, id := newTournament(t)
+
+	first := play(t, tm, id)
+
+	if _, err := tm.AmendGameResult(t.Context(), id, first.ID, []string{first.Player1, "yaweee"}, []string{"2:00.0", "2:15.0"}, "", true); err == nil {
+		t.Errorf("expected an error for an amendment that doesn't fit the bracket")
+	}
+}
@@ -0,0 +1,268 @@
+package tournament
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+	"tournament-manager/internal/tournament/formats"
+)
+
+// MatchDeadline is the time by which a pending match has to be played. It is
+// set when the match becomes available, from the tournament's
+// Options.MatchDeadlines, and dropped once the match is no longer pending.
+type MatchDeadline struct {
+	MatchID  string
+	Round    int
+	OpenedAt time.Time
+	Deadline time.Time
+	// Overdue is set once the deadline passes with the match still pending.
+	Overdue bool
+	// AutoForfeit forfeits the players who haven't reported once the match
+	// is overdue. It starts out as Options.AutoForfeit.
+	AutoForfeit bool
+	// Reported holds the players who reported ready for the match.
+	Reported []string
+}
+
+// MatchDeadlines returns the deadlines of a tournament's pending matches.
+func (tm *TournamentManager) MatchDeadlines(ctx context.Context, tournamentID string) ([]MatchDeadline, error) {
+	return tm.deadlines.List(ctx, tournamentID)
+}
+
+// syncDeadlines gives the pending matches of a tournament that have none a
+// deadline and drops the deadlines of matches that are no longer pending.
+// The caller holds the lock. Failures are logged rather than returned, since
+// the state change that prompted the sync has already been saved.
+func (tm *TournamentManager) syncDeadlines(ctx context.Context, tournamentID string, state formats.Format, now time.Time) {
+	tournament, err := tm.tournaments.Get(ctx, tournamentID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get tournament for match deadlines", "tournament_id", tournamentID, "error", err)
+		return
+	}
+
+	if len(tournament.Options.MatchDeadlines) == 0 {
+		return
+	}
+
+	deadlines, err := tm.deadlines.List(ctx, tournamentID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get match deadlines", "tournament_id", tournamentID, "error", err)
+		return
+	}
+
+	var pending []formats.Match
+	if !state.IsFinished() {
+		pending = state.GetNextMatches()
+	}
+
+	var stale []string
+	for _, deadline := range deadlines {
+		if !slices.ContainsFunc(pending, func(m formats.Match) bool { return m.ID == deadline.MatchID }) {
+			stale = append(stale, deadline.MatchID)
+		}
+	}
+
+	if len(stale) > 0 {
+		if err := tm.deadlines.Delete(ctx, tournamentID, stale); err != nil {
+			slog.ErrorContext(ctx, "Failed to drop match deadlines", "tournament_id", tournamentID, "matches", stale, "error", err)
+		}
+	}
+
+	for _, match := range pending {
+		if slices.ContainsFunc(deadlines, func(d MatchDeadline) bool { return d.MatchID == match.ID }) {
+			continue
+		}
+
+		length := tournament.Options.MatchDeadline(match.Round)
+		if length == 0 {
+			continue
+		}
+
+		deadline := MatchDeadline{
+			MatchID:     match.ID,
+			Round:       match.Round,
+			OpenedAt:    now,
+			Deadline:    now.Add(length),
+			AutoForfeit: tournament.Options.AutoForfeit,
+		}
+		if err := tm.deadlines.Save(ctx, tournamentID, deadline); err != nil {
+			slog.ErrorContext(ctx, "Failed to save match deadline", "tournament_id", tournamentID, "match_id", match.ID, "error", err)
+		}
+	}
+}
+
+// pendingDeadline returns the deadline of a pending match and the match
+// itself. The caller holds the lock.
+func (tm *TournamentManager) pendingDeadline(ctx context.Context, tournamentID, matchID string) (*MatchDeadline, formats.Match, error) {
+	state, exists := tm.activeTournaments[tournamentID]
+	if !exists {
+		return nil, formats.Match{}, fmt.Errorf("tournament %s is not active", tournamentID)
+	}
+
+	pending := state.GetNextMatches()
+	i := slices.IndexFunc(pending, func(m formats.Match) bool { return m.ID == matchID })
+	if i == -1 {
+		return nil, formats.Match{}, fmt.Errorf("match %s is not pending", matchID)
+	}
+
+	deadlines, err := tm.deadlines.List(ctx, tournamentID)
+	if err != nil {
+		return nil, formats.Match{}, err
+	}
+
+	j := slices.IndexFunc(deadlines, func(d MatchDeadline) bool { return d.MatchID == matchID })
+	if j == -1 {
+		return nil, pending[i], fmt.Errorf("match %s has no deadline", matchID)
+	}
+
+	return &deadlines[j], pending[i], nil
+}
+
+// ReportForMatch records that a player is ready to play a pending match.
+// Players who report are safe from automatic forfeits for that match.
+func (tm *TournamentManager) ReportForMatch(ctx context.Context, tournamentID, matchID, ign string) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	deadline, match, err := tm.pendingDeadline(ctx, tournamentID, matchID)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(match.Participants(), ign) {
+		return fmt.Errorf("player %s is not in match %s", ign, matchID)
+	}
+
+	if slices.Contains(deadline.Reported, ign) {
+		return nil
+	}
+
+	deadline.Reported = append(deadline.Reported, ign)
+	if err := tm.deadlines.Save(ctx, tournamentID, *deadline); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Player reported for match", "tournament_id", tournamentID, "match_id", matchID, "ign", ign)
+	return nil
+}
+
+// SetMatchDeadline overrides the deadline of a pending match, clearing its
+// overdue flag. If autoForfeit is not nil it also turns automatic forfeits
+// on or off for the match.
+func (tm *TournamentManager) SetMatchDeadline(ctx context.Context, tournamentID, matchID string, deadline time.Time, autoForfeit *bool) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	current, _, err := tm.pendingDeadline(ctx, tournamentID, matchID)
+	if err != nil {
+		return err
+	}
+
+	current.Deadline = deadline
+	current.Overdue = false
+	if autoForfeit != nil {
+		current.AutoForfeit = *autoForfeit
+	}
+
+	if err := tm.deadlines.Save(ctx, tournamentID, *current); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Match deadline overridden", "tournament_id", tournamentID, "match_id", matchID, "deadline", deadline, "auto_forfeit", current.AutoForfeit)
+	return nil
+}
+
+// EnforceDeadlines flags the pending matches whose deadline is not after now
+// with a match_overdue event and, for matches with AutoForfeit, forfeits the
+// players who didn't report. If nobody reported, the match is only flagged.
+func (tm *TournamentManager) EnforceDeadlines(ctx context.Context, now time.Time) {
+	for _, tournamentID := range tm.ListActiveTournaments() {
+		deadlines, err := tm.deadlines.List(ctx, tournamentID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to get match deadlines", "tournament_id", tournamentID, "error", err)
+			continue
+		}
+
+		for _, deadline := range deadlines {
+			if deadline.Overdue || deadline.Deadline.After(now) {
+				continue
+			}
+
+			if err := tm.enforceDeadline(ctx, tournamentID, deadline); err != nil {
+				slog.ErrorContext(ctx, "Failed to enforce match deadline", "tournament_id", tournamentID, "match_id", deadline.MatchID, "error", err)
+			}
+		}
+	}
+}
+
+// enforceDeadline flags a match as overdue and forfeits its absent players
+// under a single lock, so nothing can be played in between and the forfeits
+// can only ever hit this match.
+func (tm *TournamentManager) enforceDeadline(ctx context.Context, tournamentID string, deadline MatchDeadline) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	current, match, err := tm.pendingDeadline(ctx, tournamentID, deadline.MatchID)
+	if err != nil {
+		return err
+	}
+
+	// The deadline may have been overridden since it was listed.
+	if current.Overdue || current.Deadline.After(deadline.Deadline) {
+		return nil
+	}
+
+	current.Overdue = true
+	if err := tm.deadlines.Save(ctx, tournamentID, *current); err != nil {
+		return err
+	}
+
+	var absent []string
+	for _, player := range match.Participants() {
+		if !slices.Contains(current.Reported, player) {
+			absent = append(absent, player)
+		}
+	}
+
+	slog.WarnContext(ctx, "Match is overdue", "tournament_id", tournamentID, "match_id", match.ID, "deadline", current.Deadline, "absent", absent)
+
+	tm.publish(tournamentID, EventMatchOverdue, map[string]interface{}{
+		"match":    match,
+		"deadline": current.Deadline,
+		"absent":   absent,
+	})
+
+	if !current.AutoForfeit || len(absent) == 0 || len(absent) == len(match.Participants()) {
+		return nil
+	}
+
+	reason := fmt.Sprintf("missed the deadline of match %s", match.ID)
+	for _, player := range absent {
+		// Forfeiting an earlier absent player can end the match, after which
+		// the rest have nothing left to forfeit here.
+		if !tm.inPendingMatch(tournamentID, match.ID, player) {
+			continue
+		}
+
+		if err := tm.forfeit(ctx, tournamentID, player, ForfeitAction, reason); err != nil {
+			return fmt.Errorf("failed to forfeit %s: %w", player, err)
+		}
+	}
+
+	return nil
+}
+
+// inPendingMatch reports whether player is still due to play the pending
+// match matchID. The caller holds the lock.
+func (tm *TournamentManager) inPendingMatch(tournamentID, matchID, player string) bool {
+	state, exists := tm.activeTournaments[tournamentID]
+	if !exists || state.IsFinished() {
+		return false
+	}
+
+	return slices.ContainsFunc(state.GetNextMatches(), func(m formats.Match) bool {
+		return m.ID == matchID && slices.Contains(m.Participants(), player)
+	})
+}
@@ -0,0 +1,80 @@
+package tournament_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+	"tournament-manager/internal/tournament"
+	"tournament-manager/internal/tournament/formats"
+)
+
+func TestDeadlinesForfeitAbsentPlayers(t *testing.T) {
+	tm := tournament.NewTournamentManager(tournament.NewMemoryRepositories())
+
+	opts := formats.Options{MatchDeadlines: []int{10}, AutoForfeit: true}
+	id, err := tm.CreateTournament(t.Context(), "test", 0, "solo_single_elim", opts, nil)
+	if err != nil {
+		t.Fatalf("failed to create tournament: %v", err)
+	}
+
+	for i, ign := range []string{"senez", "kha0x", "i77_", "tauktes"} {
+		if err := tm.Signup(t.Context(), ign, ign, uint64(120000+i*1000), id); err != nil {
+			t.Fatalf("failed to sign up %v: %v", ign, err)
+		}
+	}
+
+	if _, err := tm.StartTournament(t.Context(), id); err != nil {
+		t.Fatalf("failed to start tournament: %v", err)
+	}
+
+	deadlines, _ := tm.MatchDeadlines(t.Context(), id)
+	if len(deadlines) != 2 {
+		t.Fatalf("unexpected number of deadlines, expected 2, got %d", len(deadlines))
+	}
+
+	m, _ := tm.GetNextMatches(id)
+	first, second := m[0], m[1]
+	if err := tm.ReportForMatch(t.Context(), id, first.ID, first.Player1); err != nil {
+		t.Fatalf("failed to report: %v", err)
+	}
+
+	if err := tm.ReportForMatch(t.Context(), id, first.ID, second.Player1); err == nil {
+		t.Errorf("expected reporting for someone else's match to fail")
+	}
+
+	tm.EnforceDeadlines(t.Context(), time.Now().Add(11*time.Minute))
+
+	// The player who didn't report forfeits the first match, while the
+	// second, where nobody reported, is only flagged.
+	m, _ = tm.GetNextMatches(id)
+	if len(m) != 1 || m[0].ID != second.ID {
+		t.Fatalf("unexpected pending matches, expected only %v, got %v", second.ID, m)
+	}
+
+	deadlines, _ = tm.MatchDeadlines(t.Context(), id)
+	if len(deadlines) != 1 || deadlines[0].MatchID != second.ID || !deadlines[0].Overdue {
+		t.Fatalf("unexpected deadlines, expected %v to be overdue, got %v", second.ID, deadlines)
+	}
+
+	off := false
+	if err := tm.SetMatchDeadline(t.Context(), id, second.ID, time.Now().Add(time.Hour), &off); err != nil {
+		t.Fatalf("failed to override deadline: %v", err)
+	}
+
+	deadlines, _ = tm.MatchDeadlines(t.Context(), id)
+	if deadlines[0].Overdue || deadlines[0].AutoForfeit {
+		t.Errorf("unexpected deadline after override, expected it to be reset, got %v", deadlines[0])
+	}
+
+	// Once the second match is played, the final gets a deadline of its own.
+	play(t, tm, id)
+	deadlines, _ = tm.MatchDeadlines(t.Context(), id)
+	m, _ = tm.GetNextMatches(id)
+	if len(deadlines) != 1 || deadlines[0].MatchID != m[0].ID {
+		t.Errorf("unexpected deadlines, expected one for the final %v, got %v", m[0].ID, deadlines)
+	}
+
+	if !slices.Contains(m[0].Participants(), first.Player1) {
+		t.Errorf("unexpected final, expected %v to play it, got %v", first.Player1, m[0])
+	}
+}
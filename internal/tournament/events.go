@@ -0,0 +1,296 @@
+package tournament
+
+import (
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+	"tournament-manager/internal/tournament/formats"
+)
+
+type EventType string
+
+const (
+	EventTournamentStarted     EventType = "tournament_started"
+	EventTournamentStartFailed EventType = "tournament_start_failed"
+	EventMatchCreated          EventType = "match_created"
+	EventMatchOverdue          EventType = "match_overdue"
+	EventResultSubmitted       EventType = "result_submitted"
+	EventResultCorrected       EventType = "result_corrected"
+	EventPlayerForfeited       EventType = "player_forfeited"
+	EventPlayerCheckedIn       EventType = "player_checked_in"
+	EventRoundAdvanced         EventType = "round_advanced"
+	EventTournamentCompleted   EventType = "tournament_completed"
+	EventTournamentStopped     EventType = "tournament_stopped"
+)
+
+var EventTypes = []EventType{
+	EventTournamentStarted,
+	EventTournamentStartFailed,
+	EventMatchCreated,
+	EventMatchOverdue,
+	EventResultSubmitted,
+	EventResultCorrected,
+	EventPlayerForfeited,
+	EventPlayerCheckedIn,
+	EventRoundAdvanced,
+	EventTournamentCompleted,
+	EventTournamentStopped,
+}
+
+type Event struct {
+	Type         EventType              `json:"type"`
+	TournamentID string                 `json:"tournament_id"`
+	Time         time.Time              `json:"time"`
+	Data         map[string]interface{} `json:"data,omitempty"`
+}
+
+// eventBufferSize is how many events a subscriber can fall behind before
+// new events are dropped for it.
+const eventBufferSize = 64
+
+// EventBus fans tournament events out to subscribers. Publishing never
+// blocks, so a slow subscriber can't hold up the manager.
+type EventBus struct {
+	subscribers map[string][]chan Event
+	queues      map[string][]*eventQueue
+	closed      bool
+	mu          sync.Mutex
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: make(map[string][]chan Event),
+		queues:      make(map[string][]*eventQueue),
+	}
+}
+
+// Subscribe returns a channel receiving the events of a tournament, or of
+// every tournament if tournamentID is empty, and a function to unsubscribe.
+// The channel is closed on unsubscribe or once the bus is closed.
+func (b *EventBus) Subscribe(tournamentID string) (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan Event, eventBufferSize)
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	b.subscribers[tournamentID] = append(b.subscribers[tournamentID], ch)
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if !slices.Contains(b.subscribers[tournamentID], ch) {
+			return
+		}
+
+		b.subscribers[tournamentID] = slices.DeleteFunc(b.subscribers[tournamentID], func(c chan Event) bool { return c == ch })
+		if len(b.subscribers[tournamentID]) == 0 {
+			delete(b.subscribers, tournamentID)
+		}
+		close(ch)
+	}
+}
+
+// SubscribeUnbounded is like Subscribe, but never drops events. Events the
+// subscriber hasn't received yet queue up without limit, so it is meant for
+// subscribers that have to see every event, like webhooks, rather than for
+// clients that may stop reading. Once the bus is closed the channel receives
+// the queued events before it is closed.
+func (b *EventBus) SubscribeUnbounded(tournamentID string) (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	queue := newEventQueue()
+	if b.closed {
+		queue.close()
+		return queue.out, func() {}
+	}
+	b.queues[tournamentID] = append(b.queues[tournamentID], queue)
+
+	return queue.out, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.queues[tournamentID] = slices.DeleteFunc(b.queues[tournamentID], func(q *eventQueue) bool { return q == queue })
+		if len(b.queues[tournamentID]) == 0 {
+			delete(b.queues, tournamentID)
+		}
+		queue.abandon()
+	}
+}
+
+// Close closes every subscriber channel, which ends open event streams when
+// the server shuts down.
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, subscribers := range b.subscribers {
+		for _, ch := range subscribers {
+			close(ch)
+		}
+	}
+	for _, queues := range b.queues {
+		for _, queue := range queues {
+			queue.close()
+		}
+	}
+	b.subscribers = make(map[string][]chan Event)
+	b.queues = make(map[string][]*eventQueue)
+	b.closed = true
+}
+
+func (b *EventBus) Publish(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	for _, key := range []string{event.TournamentID, ""} {
+		for _, ch := range b.subscribers[key] {
+			select {
+			case ch <- event:
+			default:
+				slog.Warn("Dropping event for slow subscriber", "tournament_id", event.TournamentID, "type", event.Type)
+			}
+		}
+
+		for _, queue := range b.queues[key] {
+			queue.push(event)
+		}
+	}
+}
+
+// eventQueue hands events to an unbounded subscriber in order, holding on to
+// them for as long as the subscriber is busy.
+type eventQueue struct {
+	out    chan Event
+	wake   chan struct{}
+	done   chan struct{}
+	events []Event
+	closed bool
+	mu     sync.Mutex
+}
+
+func newEventQueue() *eventQueue {
+	q := &eventQueue{
+		out:  make(chan Event),
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+func (q *eventQueue) push(event Event) {
+	q.mu.Lock()
+	q.events = append(q.events, event)
+	q.mu.Unlock()
+	q.signal()
+}
+
+// close closes the subscriber channel once the queued events are received.
+func (q *eventQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.signal()
+}
+
+// abandon drops the queued events and closes the subscriber channel.
+func (q *eventQueue) abandon() {
+	select {
+	case <-q.done:
+	default:
+		close(q.done)
+	}
+}
+
+func (q *eventQueue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *eventQueue) run() {
+	defer close(q.out)
+
+	for {
+		q.mu.Lock()
+		events, closed := q.events, q.closed
+		q.events = nil
+		q.mu.Unlock()
+
+		if len(events) == 0 && closed {
+			return
+		}
+
+		for _, event := range events {
+			select {
+			case q.out <- event:
+			case <-q.done:
+				return
+			}
+		}
+
+		if len(events) == 0 {
+			select {
+			case <-q.wake:
+			case <-q.done:
+				return
+			}
+		}
+	}
+}
+
+func (tm *TournamentManager) Subscribe(tournamentID string) (<-chan Event, func()) {
+	return tm.events.Subscribe(tournamentID)
+}
+
+func (tm *TournamentManager) SubscribeUnbounded(tournamentID string) (<-chan Event, func()) {
+	return tm.events.SubscribeUnbounded(tournamentID)
+}
+
+func (tm *TournamentManager) publish(tournamentID string, eventType EventType, data map[string]interface{}) {
+	tm.events.Publish(Event{Type: eventType, TournamentID: tournamentID, Data: data})
+}
+
+// publishMatchChanges compares the pending matches from before and after a
+// result and announces the matches and rounds that it opened up.
+func (tm *TournamentManager) publishMatchChanges(tournamentID string, before, after []formats.Match) {
+	round := 0
+	for _, match := range before {
+		round = max(round, match.Round)
+	}
+
+	nextRound := 0
+	for _, match := range after {
+		nextRound = max(nextRound, match.Round)
+	}
+
+	if nextRound > round {
+		tm.publish(tournamentID, EventRoundAdvanced, map[string]interface{}{
+			"round": nextRound,
+		})
+	}
+
+	for _, match := range after {
+		if slices.ContainsFunc(before, func(m formats.Match) bool { return m.ID == match.ID }) {
+			continue
+		}
+		tm.publish(tournamentID, EventMatchCreated, map[string]interface{}{
+			"match": match,
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package tournament
+
+import "testing"
+
+func TestEventBus(t *testing.T) {
+	bus := NewEventBus()
+
+	events, unsubscribe := bus.Subscribe("id")
+	all, unsubscribeAll := bus.Subscribe("")
+	defer unsubscribeAll()
+
+	bus.Publish(Event{Type: EventTournamentStarted, TournamentID: "id"})
+	bus.Publish(Event{Type: EventTournamentStarted, TournamentID: "other"})
+
+	if event := <-events; event.TournamentID != "id" || event.Time.IsZero() {
+		t.Errorf("unexpected event, got %v", event)
+	}
+
+	if len(events) != 0 {
+		t.Errorf("unexpected number of events, expected %v, got %v", 0, len(events))
+	}
+
+	if len(all) != 2 {
+		t.Errorf("unexpected number of events, expected %v, got %v", 2, len(all))
+	}
+
+	unsubscribe()
+	unsubscribe()
+	bus.Publish(Event{Type: EventTournamentStopped, TournamentID: "id"})
+
+	if _, ok := <-events; ok {
+		t.Errorf("expected the channel to be closed after unsubscribing")
+	}
+
+	bus.Close()
+	for range all {
+	}
+
+	closed, _ := bus.Subscribe("id")
+	if _, ok := <-closed; ok {
+		t.Errorf("expected subscribing to a closed bus to return a closed channel")
+	}
+}
+
+func TestEventBusUnbounded(t *testing.T) {
+	bus := NewEventBus()
+
+	events, unsubscribe := bus.SubscribeUnbounded("")
+	defer unsubscribe()
+	abandoned, unsubscribeAbandoned := bus.SubscribeUnbounded("id")
+
+	published := 10 * eventBufferSize
+	for range published {
+		bus.Publish(Event{Type: EventMatchCreated, TournamentID: "id"})
+	}
+
+	unsubscribeAbandoned()
+	for range abandoned {
+	}
+
+	bus.Close()
+
+	received := 0
+	for range events {
+		received++
+	}
+
+	if received != published {
+		t.Errorf("unexpected number of events, expected %v, got %v", published, received)
+	}
+}
@@ -0,0 +1,120 @@
+package tournament
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"tournament-manager/internal/tournament/formats"
+)
+
+const (
+	ForfeitAction    = "forfeit"
+	WithdrawAction   = "withdraw"
+	DisqualifyAction = "disqualify"
+)
+
+const (
+	PlayerRegistered   = "registered"
+	PlayerForfeited    = "forfeited"
+	PlayerWithdrawn    = "withdrawn"
+	PlayerDisqualified = "disqualified"
+)
+
+// forfeitPlayerStatus returns the Player status a forfeit action leaves a
+// player with.
+func forfeitPlayerStatus(action string) (string, error) {
+	switch action {
+	case ForfeitAction:
+		return PlayerForfeited, nil
+	case WithdrawAction:
+		return PlayerWithdrawn, nil
+	case DisqualifyAction:
+		return PlayerDisqualified, nil
+	}
+	return "", fmt.Errorf("unknown forfeit action: %s", action)
+}
+
+// Forfeit takes a player out of a tournament. A player who forfeits loses
+// their pending match as a walkover but otherwise stays in the bracket,
+// while a player who withdraws or is disqualified forfeits every match they
+// would have played. Before the tournament starts a player can only withdraw
+// or be disqualified, which leaves them out of the seeding.
+func (tm *TournamentManager) Forfeit(ctx context.Context, tournamentID, ign, action, reason string) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	return tm.forfeit(ctx, tournamentID, ign, action, reason)
+}
+
+// forfeit is Forfeit for callers that already hold the lock.
+func (tm *TournamentManager) forfeit(ctx context.Context, tournamentID, ign, action, reason string) error {
+	status, err := formats.ForfeitStatus(action)
+	if err != nil {
+		return err
+	}
+
+	playerStatus, err := forfeitPlayerStatus(action)
+	if err != nil {
+		return err
+	}
+
+	tournament, err := tm.tournaments.Get(ctx, tournamentID)
+	if err != nil {
+		return fmt.Errorf("failed to get tournament from database: %w", err)
+	}
+
+	_, active := tm.activeTournaments[tournamentID]
+	switch {
+	case tournament.Status == StatusScheduled && !active:
+		if action == ForfeitAction {
+			return fmt.Errorf("tournament %s has not started, withdraw the player instead", tournamentID)
+		}
+
+		if err := tm.players.SetStatus(ctx, tournamentID, ign, playerStatus); err != nil {
+			slog.WarnContext(ctx, err.Error())
+			return err
+		}
+
+		slog.InfoContext(ctx, "Player left tournament before the start", "tournament_id", tournamentID, "ign", ign, "action", action, "reason", reason)
+		return nil
+	case !active:
+		return fmt.Errorf("tournament %s is not active", tournamentID)
+	}
+
+	submission := Submission{Kind: action, Players: []string{ign}}
+	err = tm.recordSubmission(ctx, tournamentID, submission, func(state formats.Format) error {
+		return state.Forfeit(ctx, ign, status)
+	}, EventPlayerForfeited, map[string]interface{}{
+		"player": ign,
+		"action": action,
+		"reason": reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Player forfeited", "tournament_id", tournamentID, "ign", ign, "action", action, "reason", reason)
+	return nil
+}
+
+// submissionLabel names a submission in lists of voided games.
+func submissionLabel(submission Submission) string {
+	if submission.Kind == "" {
+		return submission.GameID
+	}
+	return fmt.Sprintf("%s %s", submission.Kind, submission.Players[0])
+}
+
+// applySubmission applies a submitted game or forfeit to a tournament state.
+func applySubmission(ctx context.Context, state formats.Format, submission Submission) error {
+	if submission.Kind == "" {
+		return state.HandleGameResult(ctx, submission.GameID, submission.Players, submission.Times)
+	}
+
+	status, err := formats.ForfeitStatus(submission.Kind)
+	if err != nil {
+		return err
+	}
+
+	return state.Forfeit(ctx, submission.Players[0], status)
+}
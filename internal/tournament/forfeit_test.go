@@ -0,0 +1,114 @@
+package tournament_test
+
+import (
+	"slices"
+	"testing"
+	"tournament-manager/internal/tournament"
+	"tournament-manager/internal/tournament/formats"
+)
+
+func TestWithdrawBeforeStart(t *testing.T) {
+	repos := tournament.NewMemoryRepositories()
+	tm := tournament.NewTournamentManager(repos)
+
+	id, err := tm.CreateTournament(t.Context(), "test", 0, "solo_single_elim", formats.Options{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tournament: %v", err)
+	}
+
+	for i, ign := range []string{"senez", "kha0x", "i77_", "tauktes"} {
+		if err := tm.Signup(t.Context(), ign, ign, uint64(120000+i*1000), id); err != nil {
+			t.Fatalf("failed to sign up %v: %v", ign, err)
+		}
+	}
+
+	if err := tm.Forfeit(t.Context(), id, "kha0x", tournament.ForfeitAction, ""); err == nil {
+		t.Errorf("expected a forfeit before the start to be refused")
+	}
+
+	if err := tm.Forfeit(t.Context(), id, "kha0x", tournament.WithdrawAction, "can't make it"); err != nil {
+		t.Fatalf("failed to withdraw player: %v", err)
+	}
+
+	seeding, err := repos.Players.SeedOrder(t.Context(), id)
+	if err != nil {
+		t.Fatalf("failed to get seed order: %v", err)
+	}
+
+	if expected := []string{"senez", "i77_", "tauktes"}; !slices.Equal(seeding, expected) {
+		t.Errorf("unexpected seeding, expected %v, got %v", expected, seeding)
+	}
+
+	if _, err := tm.StartTournament(t.Context(), id); err != nil {
+		t.Fatalf("failed to start tournament: %v", err)
+	}
+
+	m, _ := tm.GetNextMatches(id)
+	for _, match := range m {
+		if slices.Contains(match.Participants(), "kha0x") {
+			t.Errorf("unexpected match for withdrawn player: %v", match)
+		}
+	}
+}
+
+func TestForfeitDuringPlay(t *testing.T) {
+	tm, repos, id := newTournament(t)
+
+	m, _ := tm.GetNextMatches(id)
+	first := m[0]
+
+	if err := tm.Forfeit(t.Context(), id, first.Player2, tournament.ForfeitAction, "no-show"); err != nil {
+		t.Fatalf("failed to forfeit: %v", err)
+	}
+
+	if err := tm.Forfeit(t.Context(), id, first.Player2, tournament.ForfeitAction, "no-show"); err == nil {
+		t.Errorf("expected a second forfeit by the same player to be refused")
+	}
+
+	if results, _ := repos.Results.GameResults(t.Context(), id); len(results) != 0 {
+		t.Errorf("unexpected game results for a walkover, expected none, got %v", results)
+	}
+
+	// The other first round match and the final remain, with the walkover
+	// winner in the final.
+	play(t, tm, id)
+	final := play(t, tm, id)
+	if !slices.Contains(final.Participants(), first.Player1) {
+		t.Errorf("unexpected final, expected %v to play it, got %v", first.Player1, final)
+	}
+
+	results, err := repos.Results.Get(t.Context(), id)
+	if err != nil {
+		t.Fatalf("failed to get results: %v", err)
+	}
+
+	if results.Winner != final.Player1 {
+		t.Errorf("unexpected winner, expected %v, got %v", final.Player1, results.Winner)
+	}
+}
+
+func TestCorrectionKeepsForfeits(t *testing.T) {
+	tm, _, id := newTournament(t)
+
+	first := play(t, tm, id)
+
+	m, _ := tm.GetNextMatches(id)
+	second := m[0]
+	if err := tm.Forfeit(t.Context(), id, second.Player1, tournament.DisqualifyAction, "smurfing"); err != nil {
+		t.Fatalf("failed to disqualify: %v", err)
+	}
+
+	if _, err := tm.AmendGameResult(t.Context(), id, first.ID, []string{first.Player1, first.Player2}, []string{"2:30.0", "2:15.0"}, "times swapped", false); err != nil {
+		t.Fatalf("failed to amend result: %v", err)
+	}
+
+	m, _ = tm.GetNextMatches(id)
+	if len(m) != 1 {
+		t.Fatalf("unexpected pending matches, expected the final, got %v", m)
+	}
+
+	expected := []string{first.Player2, second.Player2}
+	if participants := m[0].Participants(); !slices.Equal(participants, expected) {
+		t.Errorf("unexpected final, expected %v, got %v", expected, participants)
+	}
+}
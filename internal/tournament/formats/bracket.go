@@ -0,0 +1,120 @@
+package formats
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Bracket is a structured view of a tournament for clients that draw the
+// bracket themselves instead of showing GetBracketVisualization.
+type Bracket struct {
+	TournamentID string         `json:"tournament_id"`
+	Complete     bool           `json:"complete"`
+	Winner       string         `json:"winner,omitempty"`
+	Rounds       []BracketRound `json:"rounds"`
+}
+
+// BracketRound holds the matches of one round of one bracket. Double
+// elimination has a separate BracketRound per bracket for the same round
+// number.
+type BracketRound struct {
+	Round   int            `json:"round"`
+	Bracket string         `json:"bracket,omitempty"`
+	Matches []BracketMatch `json:"matches"`
+	Byes    []BracketSlot  `json:"byes,omitempty"`
+}
+
+type BracketMatch struct {
+	ID       string        `json:"id"`
+	BestOf   int           `json:"best_of"`
+	Slots    []BracketSlot `json:"slots"`
+	Winner   string        `json:"winner,omitempty"`
+	Finished bool          `json:"finished"`
+}
+
+// BracketSlot is one player's place in a match. FeederMatch is the match the
+// player came from, for formats where one match leads into the next. Times
+// are the player's times in each game of the match, in the order they were
+// played.
+type BracketSlot struct {
+	Player      string   `json:"player"`
+	Seed        int      `json:"seed,omitempty"`
+	FeederMatch string   `json:"feeder_match,omitempty"`
+	Wins        int      `json:"wins,omitempty"`
+	Position    int      `json:"position,omitempty"`
+	Times       []uint64 `json:"times,omitempty"`
+}
+
+var bracketOrder = map[string]int{
+	"":                0,
+	BracketWinners:    0,
+	BracketLosers:     1,
+	BracketGrandFinal: 2,
+}
+
+// buildBracket groups matches and byes into rounds, winners bracket first.
+// Seeds come from the order of players. With feeders set every slot links
+// back to the player's previous match.
+func buildBracket(tournamentID string, players []string, matches []Match, byes []Bye, feeders bool) Bracket {
+	seeds := make(map[string]int, len(players))
+	for i, player := range players {
+		seeds[player] = i + 1
+	}
+
+	bracket := Bracket{
+		TournamentID: tournamentID,
+		Rounds:       []BracketRound{},
+	}
+
+	round := func(number int, name string) *BracketRound {
+		index := slices.IndexFunc(bracket.Rounds, func(r BracketRound) bool { return r.Round == number && r.Bracket == name })
+		if index == -1 {
+			bracket.Rounds = append(bracket.Rounds, BracketRound{Round: number, Bracket: name, Matches: []BracketMatch{}})
+			index = len(bracket.Rounds) - 1
+		}
+		return &bracket.Rounds[index]
+	}
+
+	for i, match := range matches {
+		bracketMatch := BracketMatch{
+			ID:       match.ID,
+			BestOf:   max(match.BestOf, 1),
+			Slots:    []BracketSlot{},
+			Winner:   match.Winner,
+			Finished: match.Finished,
+		}
+
+		for _, player := range match.Participants() {
+			slot := BracketSlot{
+				Player: player,
+				Seed:   seeds[player],
+				Wins:   match.Wins[player],
+			}
+
+			if feeders {
+				for j := i - 1; j >= 0; j-- {
+					if slices.Contains(matches[j].Participants(), player) {
+						slot.FeederMatch = matches[j].ID
+						break
+					}
+				}
+			}
+
+			bracketMatch.Slots = append(bracketMatch.Slots, slot)
+		}
+
+		r := round(match.Round, match.Bracket)
+		r.Matches = append(r.Matches, bracketMatch)
+	}
+
+	for _, bye := range byes {
+		r := round(bye.Round, bye.Bracket)
+		r.Byes = append(r.Byes, BracketSlot{Player: bye.Player, Seed: seeds[bye.Player]})
+	}
+
+	slices.SortStableFunc(bracket.Rounds, func(a, b BracketRound) int {
+		return cmp.Or(cmp.Compare(bracketOrder[a.Bracket], bracketOrder[b.Bracket]), cmp.Compare(a.Round, b.Round))
+	})
+
+	return bracket
+}
@@ -0,0 +1,58 @@
+package formats_test
+
+import (
+	"testing"
+	"tournament-manager/internal/tournament/formats"
+)
+
+func TestBracketFeeders(t *testing.T) {
+	s := formats.NewSoloSingleElimState(t.Context(), "id", []string{"senez", "kha0x", "i77_", "tauktes", "yaweee"})
+
+	b := s.GetBracket()
+	if len(b.Rounds) != 1 || len(b.Rounds[0].Matches) != 1 || len(b.Rounds[0].Byes) != 3 {
+		t.Fatalf("unexpected first round, got %+v", b.Rounds)
+	}
+
+	m := b.Rounds[0].Matches[0]
+	if m.Slots[0].Player != "tauktes" || m.Slots[0].Seed != 4 || m.Slots[1].Player != "yaweee" || m.Slots[1].Seed != 5 {
+		t.Errorf("unexpected slots, got %+v", m.Slots)
+	}
+
+	s.HandleGameResult(t.Context(), m.ID, []string{"tauktes", "yaweee"}, []uint64{135000, 120000})
+
+	b = s.GetBracket()
+	if len(b.Rounds) != 2 {
+		t.Fatalf("unexpected number of rounds, expected %v, got %v", 2, len(b.Rounds))
+	}
+
+	first := b.Rounds[1].Matches[0]
+	if first.Slots[0].Player != "senez" || first.Slots[0].FeederMatch != "" {
+		t.Errorf("unexpected slot for the top seed, got %+v", first.Slots[0])
+	}
+
+	if first.Slots[1].Player != "yaweee" || first.Slots[1].FeederMatch != m.ID {
+		t.Errorf("unexpected feeder match, expected %v, got %+v", m.ID, first.Slots[1])
+	}
+}
+
+func TestBracketDoubleElimOrder(t *testing.T) {
+	s := formats.NewSoloDoubleElimState(t.Context(), "id", []string{"senez", "i77_", "tauktes", "kha0x"}, true)
+
+	m := s.GetNextMatches()
+	s.HandleGameResult(t.Context(), m[0].ID, []string{"senez", "kha0x"}, []uint64{135000, 120000})
+	s.HandleGameResult(t.Context(), m[1].ID, []string{"i77_", "tauktes"}, []uint64{120000, 135000})
+
+	b := s.GetBracket()
+	if len(b.Rounds) != 3 {
+		t.Fatalf("unexpected number of rounds, expected %v, got %v", 3, len(b.Rounds))
+	}
+
+	if b.Rounds[0].Bracket != formats.BracketWinners || b.Rounds[1].Bracket != formats.BracketWinners || b.Rounds[2].Bracket != formats.BracketLosers {
+		t.Errorf("expected the winners bracket before the losers bracket, got %+v", b.Rounds)
+	}
+
+	losers := b.Rounds[2].Matches[0]
+	if losers.Slots[0].Player != "senez" || losers.Slots[0].FeederMatch != m[0].ID {
+		t.Errorf("unexpected feeder match, expected %v, got %+v", m[0].ID, losers.Slots[0])
+	}
+}
@@ -0,0 +1,87 @@
+package formats
+
+import (
+	"fmt"
+	"slices"
+)
+
+// forfeitStatuses maps the forfeit actions the API accepts to the status they
+// leave a player with.
+var forfeitStatuses = map[string]PlayerStatus{
+	"forfeit":    StatusForfeited,
+	"withdraw":   StatusWithdrawn,
+	"disqualify": StatusDisqualified,
+}
+
+// ForfeitStatus returns the status a forfeit action leaves a player with.
+func ForfeitStatus(action string) (PlayerStatus, error) {
+	status, ok := forfeitStatuses[action]
+	if !ok {
+		return 0, fmt.Errorf("unknown forfeit action: %s", action)
+	}
+	return status, nil
+}
+
+// hasLeft reports whether a player is out of the rest of the tournament
+// regardless of results, as opposed to having been knocked out.
+func (p PlayerStatus) hasLeft() bool {
+	return p == StatusWithdrawn || p == StatusDisqualified
+}
+
+// walkover finishes a match without playing it.
+func (m *Match) walkover(winner string) {
+	m.Winner = winner
+	m.Finished = true
+	m.Walkover = true
+}
+
+// opponent returns the other player of a two player match.
+func (m Match) opponent(player string) string {
+	if m.Player1 == player {
+		return m.Player2
+	}
+	return m.Player1
+}
+
+// pendingMatch returns the unfinished match of the given round that player
+// is in, or nil.
+func pendingMatch(matches []Match, round int, player string) *Match {
+	for i := range matches {
+		if matches[i].Round == round && !matches[i].Finished && slices.Contains(matches[i].Participants(), player) {
+			return &matches[i]
+		}
+	}
+	return nil
+}
+
+// walkoverWinner returns who wins a two player match because their opponent
+// has left the tournament, if either has.
+func walkoverWinner(m Match, status map[string]PlayerStatus) (string, bool) {
+	switch {
+	case status[m.Player1].hasLeft():
+		return m.Player2, true
+	case status[m.Player2].hasLeft():
+		return m.Player1, true
+	}
+	return "", false
+}
+
+// checkForfeit validates a forfeit by player with the given status.
+func checkForfeit(players []string, current map[string]PlayerStatus, player string, status PlayerStatus) error {
+	if !slices.Contains(players, player) {
+		return fmt.Errorf("player %s is not in this tournament", player)
+	}
+
+	if status != StatusForfeited && !status.hasLeft() {
+		return fmt.Errorf("invalid forfeit status: %d", status)
+	}
+
+	switch current[player] {
+	case StatusEliminated, StatusForfeited, StatusWinner:
+		return fmt.Errorf("player %s is already out of the tournament", player)
+	case StatusWithdrawn, StatusDisqualified:
+		return fmt.Errorf("player %s has already left the tournament", player)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package formats_test
+
+import (
+	"slices"
+	"testing"
+	"tournament-manager/internal/tournament/formats"
+)
+
+// playOut reports every pending match as won by its first player until the
+// tournament is finished.
+func playOut(t *testing.T, s formats.Format) {
+	t.Helper()
+
+	for i := 0; !s.IsFinished(); i++ {
+		if i > 100 {
+			t.Fatalf("tournament did not finish")
+		}
+
+		m := s.GetNextMatches()
+		if len(m) == 0 {
+			t.Fatalf("unfinished tournament without pending matches")
+		}
+
+		players := m[0].Participants()
+		times := make([]uint64, len(players))
+		for j := range times {
+			times[j] = uint64(120000 + j*1000)
+		}
+
+		if err := s.HandleGameResult(t.Context(), m[0].ID, players, times); err != nil {
+			t.Fatalf("failed to report %v: %v", m[0].ID, err)
+		}
+	}
+}
+
+func TestSingleElimForfeit(t *testing.T) {
+	s := formats.NewSoloSingleElimState(t.Context(), "id", []string{"senez", "i77_", "tauktes", "kha0x"})
+
+	if err := s.Forfeit(t.Context(), "kha0x", formats.StatusForfeited); err != nil {
+		t.Fatalf("failed to forfeit: %v", err)
+	}
+
+	if !s.Matches[0].Walkover || s.Matches[0].Winner != "senez" {
+		t.Errorf("unexpected match, expected a walkover for %v, got %+v", "senez", s.Matches[0])
+	}
+
+	if s.PlayerStatus["kha0x"] != formats.StatusForfeited {
+		t.Errorf("unexpected status, expected %v, got %v", formats.StatusForfeited, s.PlayerStatus["kha0x"])
+	}
+
+	if err := s.Forfeit(t.Context(), "kha0x", formats.StatusWithdrawn); err == nil {
+		t.Errorf("expected an error for a player already out")
+	}
+}
+
+func TestSingleElimWithdrawDuringBye(t *testing.T) {
+	s := formats.NewSoloSingleElimState(t.Context(), "id", []string{"senez", "i77_", "tauktes"})
+
+	if err := s.Forfeit(t.Context(), "senez", formats.StatusWithdrawn); err != nil {
+		t.Fatalf("failed to withdraw: %v", err)
+	}
+
+	if err := s.HandleGameResult(t.Context(), "match_1", []string{"i77_", "tauktes"}, []uint64{120000, 135000}); err != nil {
+		t.Fatalf("failed to report match: %v", err)
+	}
+
+	if !s.IsFinished() || s.Winner != "i77_" {
+		t.Errorf("unexpected winner, expected %v to win the final by walkover, got %v", "i77_", s.Winner)
+	}
+
+	if s.PlayerStatus["senez"] != formats.StatusWithdrawn {
+		t.Errorf("unexpected status, expected %v, got %v", formats.StatusWithdrawn, s.PlayerStatus["senez"])
+	}
+}
+
+func TestDoubleElimWithdraw(t *testing.T) {
+	s := formats.NewSoloDoubleElimState(t.Context(), "id", []string{"senez", "i77_", "tauktes", "kha0x"}, true)
+
+	if err := s.Forfeit(t.Context(), "senez", formats.StatusDisqualified); err != nil {
+		t.Fatalf("failed to disqualify: %v", err)
+	}
+
+	playOut(t, s)
+
+	if s.Winner == "senez" {
+		t.Errorf("unexpected winner, a disqualified player can't win")
+	}
+
+	for _, match := range s.Matches {
+		if slices.Contains(match.Participants(), "senez") && (!match.Walkover || match.Winner == "senez") {
+			t.Errorf("unexpected match for a disqualified player, expected a walkover loss, got %+v", match)
+		}
+	}
+}
+
+func TestRoundRobinWithdraw(t *testing.T) {
+	s := formats.NewSoloRoundRobinState(t.Context(), "id", []string{"senez", "i77_", "tauktes", "kha0x"}, nil)
+
+	if err := s.Forfeit(t.Context(), "tauktes", formats.StatusWithdrawn); err != nil {
+		t.Fatalf("failed to withdraw: %v", err)
+	}
+
+	for _, match := range s.Matches {
+		if slices.Contains(match.Participants(), "tauktes") && !match.Walkover {
+			t.Errorf("unexpected match, expected %v to be a walkover, got %+v", match.ID, match)
+		}
+	}
+
+	playOut(t, s)
+
+	standings := s.GetStandings()
+	if last := standings[len(standings)-1]; last.Player != "tauktes" || last.Losses != 3 {
+		t.Errorf("unexpected last place, expected %v with 3 losses, got %+v", "tauktes", last)
+	}
+}
+
+func TestSwissWithdraw(t *testing.T) {
+	s := formats.NewSoloSwissState(t.Context(), "id", []string{"senez", "i77_", "tauktes", "kha0x"}, 3, nil)
+
+	if err := s.Forfeit(t.Context(), "i77_", formats.StatusWithdrawn); err != nil {
+		t.Fatalf("failed to withdraw: %v", err)
+	}
+
+	playOut(t, s)
+
+	for _, match := range s.Matches {
+		if match.Round > 1 && slices.Contains(match.Participants(), "i77_") {
+			t.Errorf("unexpected match for a withdrawn player in round %v: %+v", match.Round, match)
+		}
+	}
+}
+
+func TestFFAHeatsForfeit(t *testing.T) {
+	s := formats.NewSoloFFAHeatsState(t.Context(), "id", []string{"senez", "i77_", "tauktes", "kha0x"}, 2, 1)
+
+	heat := s.GetNextMatches()[0]
+	if err := s.Forfeit(t.Context(), heat.Players[1], formats.StatusForfeited); err != nil {
+		t.Fatalf("failed to forfeit: %v", err)
+	}
+
+	if m := s.Matches[0]; !m.Walkover || m.Winner != heat.Players[0] {
+		t.Errorf("unexpected heat, expected a walkover for %v, got %+v", heat.Players[0], m)
+	}
+
+	playOut(t, s)
+
+	placements := s.GetPlacements()
+	if len(placements) != 4 || placements[3].Player != heat.Players[1] {
+		t.Errorf("unexpected placements, expected %v last, got %v", heat.Players[1], placements)
+	}
+}
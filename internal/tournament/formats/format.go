@@ -0,0 +1,166 @@
+package formats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+type PlayerStatus int
+
+const (
+	StatusActive PlayerStatus = iota
+	StatusEliminated
+	StatusBye
+	StatusWinner
+	// StatusForfeited is a player knocked out by forfeiting a match,
+	// StatusWithdrawn and StatusDisqualified players have left the
+	// tournament and forfeit every match they would still play.
+	StatusForfeited
+	StatusWithdrawn
+	StatusDisqualified
+)
+
+const (
+	BracketWinners    = "winners"
+	BracketLosers     = "losers"
+	BracketGrandFinal = "grand_final"
+)
+
+// Match is a game between Player1 and Player2, or between every player in
+// Players for formats that race more than two players at once. A match with
+// BestOf above 1 is a series that finishes once a player wins the majority of
+// its games.
+type Match struct {
+	ID       string
+	Round    int
+	Bracket  string `json:",omitempty"`
+	Player1  string
+	Player2  string
+	Players  []string       `json:",omitempty"`
+	BestOf   int            `json:",omitempty"`
+	Wins     map[string]int `json:",omitempty"`
+	Games    []string       `json:",omitempty"`
+	Winner   string
+	Finished bool
+	Walkover bool `json:",omitempty"`
+}
+
+// Participants returns every player taking part in the match.
+func (m Match) Participants() []string {
+	if len(m.Players) > 0 {
+		return m.Players
+	}
+	return []string{m.Player1, m.Player2}
+}
+
+// GameResult is one player's result in a game, the same data the manager
+// stores in the GameResult table.
+type GameResult struct {
+	GameID   string
+	Player   string
+	Position int
+	Time     uint64
+}
+
+// Format is the state of a running tournament. Every format in this package
+// implements it so the TournamentManager never has to know which one it is
+// driving. The methods that change the state take the context of the request
+// behind the change, which their log lines are written with.
+type Format interface {
+	HandleGameResult(ctx context.Context, gameID string, players []string, times []uint64) error
+	// Forfeit gives the opponents of player a walkover in the player's
+	// pending match. With StatusForfeited only that match is given up,
+	// StatusWithdrawn and StatusDisqualified also take the player out of
+	// the rest of the tournament.
+	Forfeit(ctx context.Context, player string, status PlayerStatus) error
+	GetNextMatches() []Match
+	GetMatchHistory() []Match
+	GetTournamentStatus() map[string]interface{}
+	GetBracketVisualization() string
+	GetBracket() Bracket
+	IsFinished() bool
+	GetWinner() string
+	GetPlacements() []Placement
+}
+
+// Constructor starts a new tournament of a given format with the players in
+// seeding order.
+type Constructor func(ctx context.Context, tournamentID string, players []string, opts Options) (Format, error)
+
+type registration struct {
+	displayName string
+	constructor Constructor
+	empty       func() Format
+}
+
+var registry = make(map[string]registration)
+
+// Register makes a format available under name. It is meant to be called from
+// the init function of the file implementing the format. empty returns a zero
+// state that a saved state can be decoded into, see Restore.
+func Register(name, displayName string, constructor Constructor, empty func() Format) {
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("tournament format %s registered twice", name))
+	}
+
+	registry[name] = registration{
+		displayName: displayName,
+		constructor: constructor,
+		empty:       empty,
+	}
+}
+
+// New starts a tournament of the named format.
+func New(ctx context.Context, name, tournamentID string, players []string, opts Options) (Format, error) {
+	reg, exists := registry[name]
+	if !exists {
+		return nil, fmt.Errorf("unsupported tournament format: %s", name)
+	}
+
+	return reg.constructor(ctx, tournamentID, players, opts)
+}
+
+// Restore decodes the JSON encoded state of a tournament of the named format,
+// as saved by encoding the Format with encoding/json.
+func Restore(name string, data []byte) (Format, error) {
+	reg, exists := registry[name]
+	if !exists {
+		return nil, fmt.Errorf("unsupported tournament format: %s", name)
+	}
+
+	state := reg.empty()
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to decode %s state: %w", name, err)
+	}
+
+	return state, nil
+}
+
+// Clone returns a deep copy of a state by round tripping it through the same
+// JSON encoding used to save it, so a result can be tried on the copy without
+// touching the original.
+func Clone(state Format) (Format, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	clone := reflect.New(reflect.TypeOf(state).Elem()).Interface().(Format)
+	if err := json.Unmarshal(data, clone); err != nil {
+		return nil, fmt.Errorf("failed to decode state: %w", err)
+	}
+
+	return clone, nil
+}
+
+// Available returns the registered formats keyed by name, with their display
+// names as values.
+func Available() map[string]string {
+	available := make(map[string]string, len(registry))
+	for name, reg := range registry {
+		available[name] = reg.displayName
+	}
+	return available
+}
@@ -0,0 +1,55 @@
+package formats_test
+
+import (
+	"encoding/json"
+	"testing"
+	"tournament-manager/internal/tournament/formats"
+)
+
+func TestRestore(t *testing.T) {
+	players := []string{"senez", "kha0x", "i77_", "tauktes", "yaweee"}
+
+	for name := range formats.Available() {
+		state, err := formats.New(t.Context(), name, "id", players, formats.Options{})
+		if err != nil {
+			t.Fatalf("failed to start %v: %v", name, err)
+		}
+
+		// Play the first pending match so the saved state isn't just the
+		// initial bracket.
+		match := state.GetNextMatches()[0]
+		matchPlayers := match.Players
+		if len(matchPlayers) == 0 {
+			matchPlayers = []string{match.Player1, match.Player2}
+		}
+		times := make([]uint64, len(matchPlayers))
+		for i := range times {
+			times[i] = 120000 + uint64(i)*1000
+		}
+		if err := state.HandleGameResult(t.Context(), match.ID, matchPlayers, times); err != nil {
+			t.Fatalf("failed to handle result for %v: %v", name, err)
+		}
+
+		data, err := json.Marshal(state)
+		if err != nil {
+			t.Fatalf("failed to encode %v: %v", name, err)
+		}
+
+		restored, err := formats.Restore(name, data)
+		if err != nil {
+			t.Fatalf("failed to restore %v: %v", name, err)
+		}
+
+		if restored.GetBracketVisualization() != state.GetBracketVisualization() {
+			t.Errorf("restored %v bracket differs:\n%v\nexpected:\n%v", name, restored.GetBracketVisualization(), state.GetBracketVisualization())
+		}
+
+		if len(restored.GetNextMatches()) != len(state.GetNextMatches()) {
+			t.Errorf("restored %v has %v pending matches, expected %v", name, len(restored.GetNextMatches()), len(state.GetNextMatches()))
+		}
+	}
+
+	if _, err := formats.Restore("unknown", []byte("{}")); err == nil {
+		t.Errorf("expected an error for an unknown format")
+	}
+}
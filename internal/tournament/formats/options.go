@@ -0,0 +1,125 @@
+package formats
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	TiebreakHeadToHead = "head_to_head"
+	TiebreakTotalTime  = "total_time"
+	TiebreakBestTime   = "best_time"
+	TiebreakBuchholz   = "buchholz"
+)
+
+var knownTiebreakers = []string{TiebreakHeadToHead, TiebreakTotalTime, TiebreakBestTime, TiebreakBuchholz}
+
+// Options holds the format specific settings picked when a tournament is
+// created. Formats ignore the fields that don't apply to them.
+type Options struct {
+	// BracketReset replays the grand final when the losers bracket champion
+	// wins it. Defaults to true.
+	BracketReset *bool `json:"bracket_reset,omitempty"`
+
+	// Tiebreakers orders players with the same number of wins, applied in
+	// order. Each format picks its own defaults.
+	Tiebreakers []string `json:"tiebreakers,omitempty"`
+
+	// Rounds is the number of swiss rounds. Defaults to ceil(log2(players)).
+	Rounds int `json:"rounds,omitempty"`
+
+	// LobbySize is the maximum number of players in a free for all heat and
+	// Advance how many of them move on to the next heat. Default to 4 and
+	// half the lobby.
+	LobbySize int `json:"lobby_size,omitempty"`
+	Advance   int `json:"advance,omitempty"`
+
+	// BestOf sets the series length of 1v1 matches per round, starting with
+	// round 1. The last entry applies to every later round, so [1, 3, 5]
+	// plays Bo1, then Bo3, then Bo5 from round 3 on. Defaults to Bo1.
+	BestOf []int `json:"best_of,omitempty"`
+
+	// MatchDeadlines sets how many minutes players have to finish a match
+	// once it becomes available, per round like BestOf. Matches have no
+	// deadline by default.
+	MatchDeadlines []int `json:"match_deadlines,omitempty"`
+
+	// AutoForfeit forfeits the players who didn't report for a match when
+	// its deadline passes, as long as someone did. Otherwise an overdue
+	// match is only flagged.
+	AutoForfeit bool `json:"auto_forfeit,omitempty"`
+}
+
+func (o Options) Validate() error {
+	for _, tiebreaker := range o.Tiebreakers {
+		if !slices.Contains(knownTiebreakers, tiebreaker) {
+			return fmt.Errorf("unknown tiebreaker: %v", tiebreaker)
+		}
+	}
+
+	if o.Rounds < 0 {
+		return fmt.Errorf("rounds cannot be negative")
+	}
+
+	// Lobbies of 2 leave one player without a heat whenever an odd number
+	// of players remain.
+	if o.LobbySize != 0 && o.LobbySize < 3 {
+		return fmt.Errorf("lobby size must be at least 3")
+	}
+
+	if o.Advance < 0 || (o.Advance != 0 && o.Advance >= o.lobbySize()) {
+		return fmt.Errorf("advance must be between 1 and %d", o.lobbySize()-1)
+	}
+
+	for _, bestOf := range o.BestOf {
+		if bestOf < 1 || bestOf%2 == 0 {
+			return fmt.Errorf("best of must be a positive odd number, got %d", bestOf)
+		}
+	}
+
+	for _, minutes := range o.MatchDeadlines {
+		if minutes < 1 {
+			return fmt.Errorf("match deadlines must be at least a minute, got %d", minutes)
+		}
+	}
+
+	if o.AutoForfeit && len(o.MatchDeadlines) == 0 {
+		return fmt.Errorf("auto forfeit needs match deadlines")
+	}
+	return nil
+}
+
+// MatchDeadline returns how long players have to finish a match of the given
+// round, or 0 if matches have no deadline.
+func (o Options) MatchDeadline(round int) time.Duration {
+	if len(o.MatchDeadlines) == 0 {
+		return 0
+	}
+	return time.Duration(o.MatchDeadlines[min(max(round, 1), len(o.MatchDeadlines))-1]) * time.Minute
+}
+
+func (o Options) bracketReset() bool {
+	return o.BracketReset == nil || *o.BracketReset
+}
+
+func (o Options) lobbySize() int {
+	if o.LobbySize == 0 {
+		return 4
+	}
+	return o.LobbySize
+}
+
+func (o Options) advance() int {
+	if o.Advance == 0 {
+		return max(o.lobbySize()/2, 1)
+	}
+	return o.Advance
+}
+
+func (o Options) tiebreakers(defaults ...string) []string {
+	if len(o.Tiebreakers) == 0 {
+		return defaults
+	}
+	return o.Tiebreakers
+}
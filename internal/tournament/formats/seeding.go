@@ -0,0 +1,50 @@
+package formats
+
+// seedSlots places players, given in seed order, into the slots of a bracket
+// sized to the next power of two using the standard placement where seed 1
+// meets the lowest seed and the top two seeds can only meet in the final.
+// Slots without a player are left empty, which hands the byes to the top
+// seeds.
+func seedSlots(players []string) []string {
+	order := []int{1}
+	for len(order) < len(players) {
+		next := make([]int, 0, len(order)*2)
+		for _, seed := range order {
+			next = append(next, seed, 2*len(order)+1-seed)
+		}
+		order = next
+	}
+
+	slots := make([]string, len(order))
+	for i, seed := range order {
+		if seed <= len(players) {
+			slots[i] = players[seed-1]
+		}
+	}
+	return slots
+}
+
+// slotWinners walks the seeded first round pairings and returns who moves on
+// from each pair, in bracket order: the winner of the match between the two
+// slots, or the lone player of a pair with a bye.
+func slotWinners(slots []string, matches []Match) []string {
+	winners := []string{}
+	for i := 0; i+1 < len(slots); i += 2 {
+		player1, player2 := slots[i], slots[i+1]
+
+		switch {
+		case player2 == "":
+			winners = append(winners, player1)
+		case player1 == "":
+			winners = append(winners, player2)
+		default:
+			for _, match := range matches {
+				if match.Round == 1 && match.Player1 == player1 && match.Player2 == player2 {
+					winners = append(winners, match.Winner)
+					break
+				}
+			}
+		}
+	}
+	return winners
+}
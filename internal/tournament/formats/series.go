@@ -0,0 +1,90 @@
+package formats
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SplitGameID splits a game ID into the match it belongs to and its game
+// number. Games of a best-of-N series are reported as <match_id>-g<number>,
+// a bare match ID stands for the next game of the match and returns 0.
+func SplitGameID(gameID string) (string, int) {
+	matchID, number, found := strings.Cut(gameID, "-g")
+	if !found {
+		return gameID, 0
+	}
+
+	game, err := strconv.Atoi(number)
+	if err != nil || game < 1 {
+		return gameID, 0
+	}
+
+	return matchID, game
+}
+
+// seriesLength returns the best-of length of a round. The last entry of
+// bestOf applies to every later round.
+func seriesLength(bestOf []int, round int) int {
+	if len(bestOf) == 0 {
+		return 1
+	}
+	return bestOf[min(round, len(bestOf))-1]
+}
+
+// findMatch looks up the unfinished match a game ID belongs to and checks
+// that the game was played by its participants.
+func findMatch(matches []Match, gameID string, players []string) (*Match, error) {
+	matchID, _ := SplitGameID(gameID)
+
+	matchIndex := slices.IndexFunc(matches, func(m Match) bool { return m.ID == matchID })
+	if matchIndex == -1 {
+		return nil, fmt.Errorf("match not found: %s", gameID)
+	}
+
+	match := &matches[matchIndex]
+
+	if match.Finished {
+		return nil, fmt.Errorf("match already finished: %s", matchID)
+	}
+
+	participants := match.Participants()
+	if len(players) != len(participants) || slices.ContainsFunc(participants, func(p string) bool { return !slices.Contains(players, p) }) {
+		return nil, fmt.Errorf("match %s is %v, got result for %v", matchID, participants, players)
+	}
+
+	return match, nil
+}
+
+// recordGame counts a game won by winner towards the match and finishes the
+// match once a player has won the majority of the series.
+func (m *Match) recordGame(gameID, winner string) error {
+	_, game := SplitGameID(gameID)
+	if game != 0 && game != len(m.Games)+1 {
+		return fmt.Errorf("expected game %d of match %s, got game %d", len(m.Games)+1, m.ID, game)
+	}
+
+	if m.Wins == nil {
+		m.Wins = make(map[string]int)
+	}
+
+	m.Games = append(m.Games, gameID)
+	m.Wins[winner]++
+
+	if m.Wins[winner] > max(m.BestOf, 1)/2 {
+		m.Winner = winner
+		m.Finished = true
+	}
+
+	return nil
+}
+
+// seriesScore describes the state of a best-of-N series for bracket output,
+// empty for single game matches.
+func (m Match) seriesScore() string {
+	if m.BestOf <= 1 {
+		return ""
+	}
+	return fmt.Sprintf(" (Bo%d %d-%d)", m.BestOf, m.Wins[m.Player1], m.Wins[m.Player2])
+}
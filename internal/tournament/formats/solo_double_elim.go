@@ -0,0 +1,519 @@
+package formats
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+type Bye struct {
+	Round   int
+	Bracket string
+	Player  string
+}
+
+// SoloDoubleElimState runs a winners and a losers bracket side by side. Every
+// round holds the next winners bracket matches together with the losers
+// bracket matches fed by the previous round, so players dropping down are
+// paired against the losers bracket survivors once both are known.
+type SoloDoubleElimState struct {
+	TournamentID string
+	Players      []string
+	PlayerStatus map[string]PlayerStatus
+	Losses       map[string]int
+	Matches      []Match
+	Byes         []Bye
+	CurrentRound int
+	IsComplete   bool
+	Winner       string
+	NextMatchID  int
+	BracketReset bool
+	BestOf       []int
+
+	// WinnersPool holds the unbeaten players waiting for the next winners
+	// bracket round, LosersPool the losers bracket survivors and Dropped the
+	// players that lost their last winners bracket match.
+	WinnersPool []string
+	LosersPool  []string
+	Dropped     []string
+}
+
+func init() {
+	Register("solo_double_elim", "Solo Double Elimination", func(ctx context.Context, tournamentID string, players []string, opts Options) (Format, error) {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
+
+		state := NewSoloDoubleElimState(ctx, tournamentID, players, opts.bracketReset(), opts.BestOf...)
+		if state == nil {
+			return nil, fmt.Errorf("failed to create tournament state")
+		}
+		return state, nil
+	}, func() Format { return &SoloDoubleElimState{} })
+}
+
+// NewSoloDoubleElimState starts a double elimination bracket with players given
+// in seed order. bestOf sets the series length per round, see Options.BestOf.
+func NewSoloDoubleElimState(ctx context.Context, tournamentID string, players []string, bracketReset bool, bestOf ...int) *SoloDoubleElimState {
+	if len(players) < 2 {
+		slog.WarnContext(ctx, "Not enough players for double elimination", "count", len(players))
+		return nil
+	}
+
+	playerStatus := make(map[string]PlayerStatus)
+	losses := make(map[string]int)
+	for _, player := range players {
+		playerStatus[player] = StatusActive
+		losses[player] = 0
+	}
+
+	state := &SoloDoubleElimState{
+		TournamentID: tournamentID,
+		Players:      players,
+		PlayerStatus: playerStatus,
+		Losses:       losses,
+		Matches:      []Match{},
+		Byes:         []Bye{},
+		CurrentRound: 1,
+		NextMatchID:  1,
+		BracketReset: bracketReset,
+		BestOf:       bestOf,
+		WinnersPool:  slices.Clone(players),
+		LosersPool:   []string{},
+		Dropped:      []string{},
+	}
+
+	state.generateRoundMatches(ctx)
+
+	return state
+}
+
+func (s *SoloDoubleElimState) generateRoundMatches(ctx context.Context) {
+	if len(s.WinnersPool) == 1 && len(s.LosersPool)+len(s.Dropped) == 1 {
+		lbChampion := append(s.LosersPool, s.Dropped...)[0]
+		s.LosersPool = []string{lbChampion}
+		s.Dropped = []string{}
+		s.addMatch(ctx, BracketGrandFinal, s.WinnersPool[0], lbChampion)
+		return
+	}
+
+	slog.DebugContext(ctx, "Generating matches for round", "round", s.CurrentRound, "winners", len(s.WinnersPool), "losers", len(s.LosersPool)+len(s.Dropped))
+
+	if s.CurrentRound == 1 {
+		s.WinnersPool = s.pairSeeded(ctx, seedSlots(s.WinnersPool))
+	} else if len(s.WinnersPool) > 1 {
+		s.WinnersPool = s.pairPlayers(ctx, BracketWinners, s.WinnersPool)
+	}
+
+	losers := []string{}
+	if len(s.LosersPool) == len(s.Dropped) {
+		// Cross the pairings so players dropping down meet survivors from the
+		// other half of the bracket, which avoids early rematches.
+		for i := range s.LosersPool {
+			losers = append(losers, s.LosersPool[i], s.Dropped[len(s.Dropped)-1-i])
+		}
+	} else {
+		losers = append(slices.Clone(s.LosersPool), s.Dropped...)
+	}
+	s.Dropped = []string{}
+
+	if len(losers) > 1 {
+		losers = s.pairPlayers(ctx, BracketLosers, losers)
+	}
+	s.LosersPool = losers
+}
+
+// pairPlayers creates matches for consecutive players in the given bracket and
+// returns the player left over with a bye, if any.
+func (s *SoloDoubleElimState) pairPlayers(ctx context.Context, bracket string, players []string) []string {
+	waiting := []string{}
+
+	if len(players)%2 == 1 {
+		byePlayer := players[len(players)-1]
+		players = players[:len(players)-1]
+		waiting = append(waiting, byePlayer)
+		s.PlayerStatus[byePlayer] = StatusBye
+		s.Byes = append(s.Byes, Bye{Round: s.CurrentRound, Bracket: bracket, Player: byePlayer})
+		slog.DebugContext(ctx, "Player gets bye", "player", byePlayer, "round", s.CurrentRound, "bracket", bracket)
+	}
+
+	for i := 0; i < len(players); i += 2 {
+		s.addMatch(ctx, bracket, players[i], players[i+1])
+	}
+
+	return waiting
+}
+
+// pairSeeded creates the first winners bracket round from seeded slots and
+// returns the players with a bye, who are always the top seeds.
+func (s *SoloDoubleElimState) pairSeeded(ctx context.Context, slots []string) []string {
+	waiting := []string{}
+
+	for i := 0; i+1 < len(slots); i += 2 {
+		if slots[i] == "" || slots[i+1] == "" {
+			byePlayer := slots[i] + slots[i+1]
+			waiting = append(waiting, byePlayer)
+			s.PlayerStatus[byePlayer] = StatusBye
+			s.Byes = append(s.Byes, Bye{Round: s.CurrentRound, Bracket: BracketWinners, Player: byePlayer})
+			slog.DebugContext(ctx, "Player gets bye", "player", byePlayer, "round", s.CurrentRound, "bracket", BracketWinners)
+			continue
+		}
+
+		s.addMatch(ctx, BracketWinners, slots[i], slots[i+1])
+	}
+
+	return waiting
+}
+
+func (s *SoloDoubleElimState) addMatch(ctx context.Context, bracket, player1, player2 string) {
+	match := Match{
+		ID:       fmt.Sprintf("match_%d", s.NextMatchID),
+		Round:    s.CurrentRound,
+		Bracket:  bracket,
+		Player1:  player1,
+		Player2:  player2,
+		BestOf:   seriesLength(s.BestOf, s.CurrentRound),
+		Winner:   "",
+		Finished: false,
+	}
+	s.Matches = append(s.Matches, match)
+	s.NextMatchID++
+
+	slog.DebugContext(ctx, "Created match", "match_id", match.ID, "bracket", bracket, "player1", match.Player1, "player2", match.Player2)
+}
+
+func (s *SoloDoubleElimState) HandleGameResult(ctx context.Context, gameID string, players []string, times []uint64) error {
+	if len(players) != len(times) {
+		return fmt.Errorf("players and times arrays must have the same length")
+	}
+
+	match, err := findMatch(s.Matches, gameID, players)
+	if err != nil {
+		return err
+	}
+
+	if len(players) != 2 || !slices.Contains(players, match.Player1) || !slices.Contains(players, match.Player2) {
+		return fmt.Errorf("match %s is %s vs %s, got result for %v", match.ID, match.Player1, match.Player2, players)
+	}
+
+	winnerIndex := 0
+	for i, time := range times {
+		if time < times[winnerIndex] {
+			winnerIndex = i
+		}
+	}
+
+	if err := match.recordGame(gameID, players[winnerIndex]); err != nil {
+		return err
+	}
+
+	if !match.Finished {
+		slog.InfoContext(ctx, "Game result processed", "game_id", gameID, "winner", players[winnerIndex], "wins", match.Wins)
+		return nil
+	}
+
+	s.finishMatch(ctx, match)
+	s.resolveWalkovers(ctx)
+
+	return nil
+}
+
+// Forfeit counts a walkover for the opponent of player as a loss, which
+// drops them to the losers bracket or knocks them out like any other loss.
+// Players leaving the tournament also forfeit every match they are paired
+// into later.
+func (s *SoloDoubleElimState) Forfeit(ctx context.Context, player string, status PlayerStatus) error {
+	if err := checkForfeit(s.Players, s.PlayerStatus, player, status); err != nil {
+		return err
+	}
+
+	match := pendingMatch(s.Matches, s.CurrentRound, player)
+	if match == nil && status == StatusForfeited {
+		return fmt.Errorf("player %s has no pending match to forfeit", player)
+	}
+
+	if status.hasLeft() {
+		s.PlayerStatus[player] = status
+	}
+
+	if match != nil {
+		match.walkover(match.opponent(player))
+		s.finishMatch(ctx, match)
+	}
+
+	if status == StatusForfeited && s.PlayerStatus[player] == StatusEliminated {
+		s.PlayerStatus[player] = StatusForfeited
+	}
+	slog.InfoContext(ctx, "Player forfeited", "player", player, "status", status)
+
+	s.resolveWalkovers(ctx)
+	return nil
+}
+
+// finishMatch counts the loss of a finished match and advances once the
+// round is over.
+func (s *SoloDoubleElimState) finishMatch(ctx context.Context, match *Match) {
+	winner := match.Winner
+	loser := match.opponent(winner)
+
+	s.Losses[loser]++
+
+	slog.InfoContext(ctx, "Match result processed", "match_id", match.ID, "bracket", match.Bracket, "winner", winner, "walkover", match.Walkover)
+
+	if match.Bracket == BracketGrandFinal {
+		s.handleGrandFinal(ctx, winner, loser)
+		return
+	}
+
+	if s.Losses[loser] >= 2 && !s.PlayerStatus[loser].hasLeft() {
+		s.PlayerStatus[loser] = StatusEliminated
+	}
+
+	if s.isRoundComplete() {
+		s.advanceToNextRound(ctx)
+	}
+}
+
+// resolveWalkovers finishes the pending matches of players who have left the
+// tournament, including the ones created by advancing.
+func (s *SoloDoubleElimState) resolveWalkovers(ctx context.Context) {
+	for i := 0; i < len(s.Matches); i++ {
+		if s.Matches[i].Finished {
+			continue
+		}
+
+		if winner, ok := walkoverWinner(s.Matches[i], s.PlayerStatus); ok {
+			s.Matches[i].walkover(winner)
+			s.finishMatch(ctx, &s.Matches[i])
+		}
+	}
+}
+
+func (s *SoloDoubleElimState) handleGrandFinal(ctx context.Context, winner, loser string) {
+	// The winners bracket champion only loses the tournament on their second
+	// loss, so a first loss in the grand final forces a reset match.
+	if s.Losses[loser] == 1 && s.BracketReset && !s.PlayerStatus[loser].hasLeft() {
+		s.CurrentRound++
+		slog.InfoContext(ctx, "Grand final bracket reset", "round", s.CurrentRound)
+		s.addMatch(ctx, BracketGrandFinal, winner, loser)
+		return
+	}
+
+	if !s.PlayerStatus[loser].hasLeft() {
+		s.PlayerStatus[loser] = StatusEliminated
+	}
+	s.Winner = winner
+	s.PlayerStatus[winner] = StatusWinner
+	s.IsComplete = true
+	slog.InfoContext(ctx, "Tournament complete", "winner", s.Winner)
+}
+
+func (s *SoloDoubleElimState) isRoundComplete() bool {
+	for _, match := range s.Matches {
+		if match.Round == s.CurrentRound && !match.Finished {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *SoloDoubleElimState) advanceToNextRound(ctx context.Context) {
+	winnersPool := []string{}
+	dropped := []string{}
+	losersPool := []string{}
+
+	for _, match := range s.Matches {
+		if match.Round != s.CurrentRound {
+			continue
+		}
+
+		loser := match.Player1
+		if loser == match.Winner {
+			loser = match.Player2
+		}
+
+		switch match.Bracket {
+		case BracketWinners:
+			winnersPool = append(winnersPool, match.Winner)
+			dropped = append(dropped, loser)
+		case BracketLosers:
+			losersPool = append(losersPool, match.Winner)
+		}
+	}
+
+	for _, player := range s.WinnersPool {
+		s.endBye(player)
+		winnersPool = append(winnersPool, player)
+	}
+
+	// Keep the seeded bracket order so the top seeds stay apart after the
+	// first round byes.
+	if s.CurrentRound == 1 {
+		winnersPool = slotWinners(seedSlots(s.Players), s.Matches)
+	}
+	for _, player := range s.LosersPool {
+		s.endBye(player)
+		losersPool = append(losersPool, player)
+	}
+
+	s.WinnersPool = winnersPool
+	s.LosersPool = losersPool
+	s.Dropped = dropped
+
+	s.CurrentRound++
+	slog.InfoContext(ctx, "Advancing to next round", "round", s.CurrentRound, "winners", len(winnersPool), "losers", len(losersPool)+len(dropped))
+
+	s.generateRoundMatches(ctx)
+}
+
+// endBye makes a player who sat out the round active again, unless they
+// left the tournament in the meantime.
+func (s *SoloDoubleElimState) endBye(player string) {
+	if s.PlayerStatus[player] == StatusBye {
+		s.PlayerStatus[player] = StatusActive
+	}
+}
+
+func (s *SoloDoubleElimState) GetNextMatches() []Match {
+	if s.IsComplete {
+		return []Match{}
+	}
+
+	var nextMatches []Match
+	for _, match := range s.Matches {
+		if match.Round == s.CurrentRound && !match.Finished {
+			nextMatches = append(nextMatches, match)
+		}
+	}
+
+	return nextMatches
+}
+
+func (s *SoloDoubleElimState) GetMatchHistory() []Match {
+	var history []Match
+	for _, match := range s.Matches {
+		if match.Finished {
+			history = append(history, match)
+		}
+	}
+	return history
+}
+
+func (s *SoloDoubleElimState) GetTournamentStatus() map[string]interface{} {
+	winnersBracket := []string{}
+	losersBracket := []string{}
+	eliminatedPlayers := []string{}
+	byePlayers := []string{}
+
+	for _, player := range s.Players {
+		switch {
+		case s.PlayerStatus[player] == StatusEliminated:
+			eliminatedPlayers = append(eliminatedPlayers, player)
+			continue
+		case s.PlayerStatus[player] == StatusBye:
+			byePlayers = append(byePlayers, player)
+		}
+
+		if s.Losses[player] == 0 {
+			winnersBracket = append(winnersBracket, player)
+		} else {
+			losersBracket = append(losersBracket, player)
+		}
+	}
+
+	return map[string]interface{}{
+		"tournament_id":      s.TournamentID,
+		"current_round":      s.CurrentRound,
+		"is_complete":        s.IsComplete,
+		"winner":             s.Winner,
+		"winners_bracket":    winnersBracket,
+		"losers_bracket":     losersBracket,
+		"eliminated_players": eliminatedPlayers,
+		"players_with_bye":   byePlayers,
+		"bracket_reset":      s.BracketReset,
+		"next_matches":       s.GetNextMatches(),
+	}
+}
+
+func (s *SoloDoubleElimState) IsFinished() bool {
+	return s.IsComplete
+}
+
+func (s *SoloDoubleElimState) GetWinner() string {
+	return s.Winner
+}
+
+// GetPlacements ranks players by the round of their final loss, once the
+// tournament is complete.
+func (s *SoloDoubleElimState) GetPlacements() []Placement {
+	if !s.IsComplete {
+		return []Placement{}
+	}
+	return placementsByElimination(s.Winner, s.Matches)
+}
+
+func (s *SoloDoubleElimState) GetBracketVisualization() string {
+	result := fmt.Sprintf("Tournament: %s\n", s.TournamentID)
+	result += fmt.Sprintf("Current Round: %d\n", s.CurrentRound)
+	result += fmt.Sprintf("Status: %s\n", func() string {
+		if s.IsComplete {
+			return fmt.Sprintf("COMPLETE - Winner: %s", s.Winner)
+		}
+		return "IN PROGRESS"
+	}())
+	result += "\n"
+
+	sections := []struct {
+		bracket string
+		title   string
+	}{
+		{BracketWinners, "Winners Bracket"},
+		{BracketLosers, "Losers Bracket"},
+		{BracketGrandFinal, "Grand Final"},
+	}
+
+	for _, section := range sections {
+		body := ""
+		for round := 1; round <= s.CurrentRound; round++ {
+			roundBody := ""
+			for _, match := range s.Matches {
+				if match.Round != round || match.Bracket != section.bracket {
+					continue
+				}
+
+				status := "PENDING"
+				if match.Finished {
+					status = fmt.Sprintf("WINNER: %s", match.Winner)
+				}
+				roundBody += fmt.Sprintf("  %s vs %s - %s%s\n", match.Player1, match.Player2, status, match.seriesScore())
+			}
+
+			for _, bye := range s.Byes {
+				if bye.Round == round && bye.Bracket == section.bracket {
+					roundBody += fmt.Sprintf("  %s - BYE\n", bye.Player)
+				}
+			}
+
+			if roundBody != "" {
+				body += fmt.Sprintf("--- Round %d ---\n%s", round, roundBody)
+			}
+		}
+
+		if body != "" {
+			result += fmt.Sprintf("=== %s ===\n%s\n", section.title, body)
+		}
+	}
+
+	return result
+}
+
+// GetBracket returns the winners bracket, losers bracket and grand final
+// rounds in that order. Players dropping into the losers bracket are linked
+// to the match they lost.
+func (s *SoloDoubleElimState) GetBracket() Bracket {
+	bracket := buildBracket(s.TournamentID, s.Players, s.Matches, s.Byes, true)
+	bracket.Complete = s.IsComplete
+	bracket.Winner = s.Winner
+	return bracket
+}
@@ -0,0 +1,108 @@
+package formats_test
+
+import (
+	"fmt"
+	"testing"
+	"tournament-manager/internal/tournament/formats"
+)
+
+func TestDoubleElim(t *testing.T) {
+	s := formats.NewSoloDoubleElimState(t.Context(), "id", []string{
+		"senez",
+		"i77_",
+		"tauktes",
+		"kha0x",
+	}, true)
+
+	m := s.GetNextMatches()
+	if len(m) != 2 {
+		t.Fatalf("unexpected number of matches, expected %v, got %v", 2, len(m))
+	}
+
+	// Winners bracket round 1: kha0x and i77_ win.
+	s.HandleGameResult(t.Context(), m[0].ID, []string{"senez", "kha0x"}, []uint64{135000, 120000})
+	s.HandleGameResult(t.Context(), m[1].ID, []string{"i77_", "tauktes"}, []uint64{120000, 135000})
+
+	m = s.GetNextMatches()
+	if len(m) != 2 {
+		t.Fatalf("unexpected number of matches, expected %v, got %v", 2, len(m))
+	}
+
+	if m[0].Bracket != formats.BracketWinners || m[0].Player1 != "kha0x" || m[0].Player2 != "i77_" {
+		t.Errorf("unexpected winners final, got %v", m[0])
+	}
+
+	if m[1].Bracket != formats.BracketLosers || m[1].Player1 != "senez" || m[1].Player2 != "tauktes" {
+		t.Errorf("unexpected losers match, got %v", m[1])
+	}
+
+	s.HandleGameResult(t.Context(), m[0].ID, []string{"kha0x", "i77_"}, []uint64{120000, 135000})
+	s.HandleGameResult(t.Context(), m[1].ID, []string{"senez", "tauktes"}, []uint64{120000, 135000})
+
+	m = s.GetNextMatches()
+	if len(m) != 1 || m[0].Bracket != formats.BracketLosers || m[0].Player1 != "senez" || m[0].Player2 != "i77_" {
+		t.Fatalf("unexpected losers final, got %v", m)
+	}
+
+	s.HandleGameResult(t.Context(), m[0].ID, []string{"senez", "i77_"}, []uint64{135000, 120000})
+
+	m = s.GetNextMatches()
+	if len(m) != 1 || m[0].Bracket != formats.BracketGrandFinal || m[0].Player1 != "kha0x" || m[0].Player2 != "i77_" {
+		t.Fatalf("unexpected grand final, got %v", m)
+	}
+
+	// i77_ comes back from the losers bracket, which resets the bracket.
+	s.HandleGameResult(t.Context(), m[0].ID, []string{"kha0x", "i77_"}, []uint64{135000, 120000})
+	if s.IsFinished() {
+		t.Fatalf("expected a bracket reset after the losers bracket champion won")
+	}
+
+	m = s.GetNextMatches()
+	if len(m) != 1 || m[0].Bracket != formats.BracketGrandFinal {
+		t.Fatalf("unexpected bracket reset, got %v", m)
+	}
+
+	s.HandleGameResult(t.Context(), m[0].ID, []string{"i77_", "kha0x"}, []uint64{120000, 135000})
+	if !s.IsFinished() || s.GetWinner() != "i77_" {
+		t.Errorf("unexpected winner, expected %v, got %v", "i77_", s.GetWinner())
+	}
+
+	fmt.Println(s.GetBracketVisualization())
+}
+
+func TestDoubleElimNoBracketReset(t *testing.T) {
+	s := formats.NewSoloDoubleElimState(t.Context(), "id", []string{"senez", "kha0x", "i77_"}, false)
+
+	for !s.IsFinished() {
+		m := s.GetNextMatches()
+		if len(m) == 0 {
+			t.Fatalf("tournament stalled without pending matches")
+		}
+
+		// The second player listed always wins.
+		if err := s.HandleGameResult(t.Context(), m[0].ID, []string{m[0].Player1, m[0].Player2}, []uint64{135000, 120000}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	history := s.GetMatchHistory()
+	last := history[len(history)-1]
+	if last.Bracket != formats.BracketGrandFinal {
+		t.Errorf("expected the tournament to end on the grand final, got %v", last)
+	}
+
+	if len(history) != 4 {
+		t.Errorf("unexpected number of matches, expected %v, got %v", 4, len(history))
+	}
+
+	fmt.Println(s.GetBracketVisualization())
+}
+
+func TestDoubleElimRejectsWrongPlayers(t *testing.T) {
+	s := formats.NewSoloDoubleElimState(t.Context(), "id", []string{"senez", "kha0x", "i77_", "tauktes"}, true)
+
+	m := s.GetNextMatches()
+	if err := s.HandleGameResult(t.Context(), m[0].ID, []string{"senez", "i77_"}, []uint64{135000, 120000}); err == nil {
+		t.Errorf("expected an error for players not in the match")
+	}
+}
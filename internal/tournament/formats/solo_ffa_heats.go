@@ -0,0 +1,448 @@
+package formats
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// SoloFFAHeatsState races players in lobbies instead of 1v1 matches. Every
+// round splits the remaining players into heats of at most LobbySize, the
+// fastest Advance players of each heat move on, and once everyone left fits
+// in one lobby that heat decides the final placements.
+type SoloFFAHeatsState struct {
+	TournamentID string
+	Players      []string
+	PlayerStatus map[string]PlayerStatus
+	Matches      []Match
+	Results      []GameResult
+	CurrentRound int
+	LobbySize    int
+	Advance      int
+	IsComplete   bool
+	Winner       string
+	NextMatchID  int
+
+	// Remaining holds the players still in, best first. Eliminated holds the
+	// players knocked out in each round, also best first. Forfeited holds
+	// the players who forfeited or left during the current round.
+	Remaining  []string
+	Eliminated [][]string
+	Forfeited  []string `json:",omitempty"`
+}
+
+func init() {
+	Register("solo_ffa_heats", "Solo Free For All Heats", func(ctx context.Context, tournamentID string, players []string, opts Options) (Format, error) {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
+
+		state := NewSoloFFAHeatsState(ctx, tournamentID, players, opts.lobbySize(), opts.advance())
+		if state == nil {
+			return nil, fmt.Errorf("failed to create tournament state")
+		}
+		return state, nil
+	}, func() Format { return &SoloFFAHeatsState{} })
+}
+
+func NewSoloFFAHeatsState(ctx context.Context, tournamentID string, players []string, lobbySize int, advance int) *SoloFFAHeatsState {
+	if len(players) < 2 {
+		slog.WarnContext(ctx, "Not enough players for free for all heats", "count", len(players))
+		return nil
+	}
+
+	playerStatus := make(map[string]PlayerStatus)
+	for _, player := range players {
+		playerStatus[player] = StatusActive
+	}
+
+	state := &SoloFFAHeatsState{
+		TournamentID: tournamentID,
+		Players:      players,
+		PlayerStatus: playerStatus,
+		Matches:      []Match{},
+		Results:      []GameResult{},
+		CurrentRound: 1,
+		LobbySize:    lobbySize,
+		Advance:      advance,
+		NextMatchID:  1,
+		Remaining:    slices.Clone(players),
+		Eliminated:   [][]string{},
+	}
+
+	state.generateRoundMatches(ctx)
+
+	return state
+}
+
+// generateRoundMatches snakes the remaining players across as few lobbies as
+// possible so every heat gets a similar spread of players. A heat left with a
+// single player, which only lobbies of 2 can produce, is a walkover.
+func (s *SoloFFAHeatsState) generateRoundMatches(ctx context.Context) {
+	lobbyCount := (len(s.Remaining) + s.LobbySize - 1) / s.LobbySize
+	lobbies := make([][]string, lobbyCount)
+
+	for i, player := range s.Remaining {
+		lobby := i % lobbyCount
+		if (i/lobbyCount)%2 == 1 {
+			lobby = lobbyCount - 1 - lobby
+		}
+		lobbies[lobby] = append(lobbies[lobby], player)
+	}
+
+	slog.DebugContext(ctx, "Generating heats for round", "round", s.CurrentRound, "players", len(s.Remaining), "heats", lobbyCount)
+
+	for _, lobby := range lobbies {
+		match := Match{
+			ID:       fmt.Sprintf("match_%d", s.NextMatchID),
+			Round:    s.CurrentRound,
+			Players:  lobby,
+			Winner:   "",
+			Finished: false,
+		}
+		if len(lobby) == 1 {
+			match.walkover(lobby[0])
+		}
+		s.Matches = append(s.Matches, match)
+		s.NextMatchID++
+
+		slog.DebugContext(ctx, "Created heat", "match_id", match.ID, "players", match.Players, "walkover", match.Walkover)
+	}
+}
+
+func (s *SoloFFAHeatsState) isFinalRound() bool {
+	return len(s.Remaining) <= s.LobbySize
+}
+
+func (s *SoloFFAHeatsState) HandleGameResult(ctx context.Context, gameID string, players []string, times []uint64) error {
+	if len(players) != len(times) {
+		return fmt.Errorf("players and times arrays must have the same length")
+	}
+
+	matchIndex := slices.IndexFunc(s.Matches, func(m Match) bool { return m.ID == gameID })
+	if matchIndex == -1 {
+		return fmt.Errorf("match not found: %s", gameID)
+	}
+
+	match := &s.Matches[matchIndex]
+
+	if match.Finished {
+		return fmt.Errorf("match already finished: %s", gameID)
+	}
+
+	if len(players) != len(match.Players) || slices.ContainsFunc(match.Players, func(p string) bool { return !slices.Contains(players, p) }) {
+		return fmt.Errorf("heat %s is %v, got result for %v", gameID, match.Players, players)
+	}
+
+	results := make([]GameResult, len(players))
+	for i, player := range players {
+		position := 1
+		for _, time := range times {
+			if time < times[i] {
+				position++
+			}
+		}
+		results[i] = GameResult{GameID: gameID, Player: player, Position: position, Time: times[i]}
+	}
+
+	slices.SortStableFunc(results, func(a, b GameResult) int {
+		return cmp.Compare(a.Position, b.Position)
+	})
+
+	match.Winner = results[0].Player
+	match.Finished = true
+	s.Results = append(s.Results, results...)
+
+	slog.InfoContext(ctx, "Heat result processed", "match_id", gameID, "winner", match.Winner)
+
+	if s.isRoundComplete() {
+		s.advanceToNextRound(ctx)
+	}
+
+	return nil
+}
+
+// Forfeit takes player out of their heat, knocking them out behind everyone
+// who finished the round. A heat left with a single player is a walkover.
+// Players who already made it through their heat can only leave the
+// tournament, not forfeit.
+func (s *SoloFFAHeatsState) Forfeit(ctx context.Context, player string, status PlayerStatus) error {
+	if err := checkForfeit(s.Players, s.PlayerStatus, player, status); err != nil {
+		return err
+	}
+
+	heat := pendingMatch(s.Matches, s.CurrentRound, player)
+	if heat == nil && status == StatusForfeited {
+		return fmt.Errorf("player %s has no pending heat to forfeit", player)
+	}
+
+	s.PlayerStatus[player] = status
+	s.Forfeited = append(s.Forfeited, player)
+	slog.InfoContext(ctx, "Player forfeited", "player", player, "status", status)
+
+	if heat == nil {
+		return nil
+	}
+
+	heat.Players = slices.DeleteFunc(slices.Clone(heat.Players), func(p string) bool { return p == player })
+	if len(heat.Players) > 1 {
+		return nil
+	}
+
+	if len(heat.Players) == 1 {
+		heat.walkover(heat.Players[0])
+	} else {
+		heat.walkover("")
+	}
+
+	if s.isRoundComplete() {
+		s.advanceToNextRound(ctx)
+	}
+
+	return nil
+}
+
+// finishers returns the results of a heat, fastest first. Everyone left in a
+// walkover heat finishes first.
+func (s *SoloFFAHeatsState) finishers(match Match) []GameResult {
+	if !match.Walkover {
+		return s.heatResults(match.ID)
+	}
+
+	results := []GameResult{}
+	for _, player := range match.Players {
+		results = append(results, GameResult{GameID: match.ID, Player: player, Position: 1})
+	}
+	return results
+}
+
+func (s *SoloFFAHeatsState) isRoundComplete() bool {
+	for _, match := range s.Matches {
+		if match.Round == s.CurrentRound && !match.Finished {
+			return false
+		}
+	}
+	return true
+}
+
+// heatResults returns the results of a heat, fastest first.
+func (s *SoloFFAHeatsState) heatResults(matchID string) []GameResult {
+	var results []GameResult
+	for _, result := range s.Results {
+		if result.GameID == matchID {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+func (s *SoloFFAHeatsState) advanceToNextRound(ctx context.Context) {
+	if s.isFinalRound() {
+		final := s.finishers(s.Matches[len(s.Matches)-1])
+
+		s.Remaining = []string{}
+		for _, result := range final {
+			s.Remaining = append(s.Remaining, result.Player)
+			s.PlayerStatus[result.Player] = StatusEliminated
+		}
+
+		if len(s.Forfeited) > 0 {
+			s.Eliminated = append(s.Eliminated, s.Forfeited)
+			s.Forfeited = nil
+		}
+
+		if len(final) > 0 {
+			s.Winner = final[0].Player
+			s.PlayerStatus[s.Winner] = StatusWinner
+		}
+		s.IsComplete = true
+		slog.InfoContext(ctx, "Tournament complete", "winner", s.Winner)
+		return
+	}
+
+	var advancing, eliminated []GameResult
+	for _, match := range s.Matches {
+		if match.Round != s.CurrentRound {
+			continue
+		}
+
+		// Always knock out at least one player per heat so small heats
+		// can't stall the tournament.
+		results := s.finishers(match)
+		if match.Walkover {
+			advancing = append(advancing, results...)
+			continue
+		}
+
+		cutoff := min(s.Advance, len(results)-1)
+		advancing = append(advancing, results[:cutoff]...)
+		eliminated = append(eliminated, results[cutoff:]...)
+	}
+
+	byFinish := func(a, b GameResult) int {
+		return cmp.Or(cmp.Compare(a.Position, b.Position), cmp.Compare(a.Time, b.Time))
+	}
+	slices.SortStableFunc(advancing, byFinish)
+	slices.SortStableFunc(eliminated, byFinish)
+
+	s.Remaining = []string{}
+	for _, result := range advancing {
+		if !s.PlayerStatus[result.Player].hasLeft() {
+			s.Remaining = append(s.Remaining, result.Player)
+		}
+	}
+
+	knockedOut := []string{}
+	for _, result := range eliminated {
+		knockedOut = append(knockedOut, result.Player)
+		s.PlayerStatus[result.Player] = StatusEliminated
+	}
+	knockedOut = append(knockedOut, s.Forfeited...)
+	s.Forfeited = nil
+	s.Eliminated = append(s.Eliminated, knockedOut)
+
+	s.CurrentRound++
+	slog.InfoContext(ctx, "Advancing to next round", "round", s.CurrentRound, "remaining_players", len(s.Remaining))
+
+	s.generateRoundMatches(ctx)
+
+	// With players gone a round can end up with nothing but walkovers.
+	if s.isRoundComplete() {
+		s.advanceToNextRound(ctx)
+	}
+}
+
+// GetPlacements ranks the finalists by their final heat, followed by everyone
+// else in the order they were knocked out, latest first.
+func (s *SoloFFAHeatsState) GetPlacements() []Placement {
+	if !s.IsComplete {
+		return []Placement{}
+	}
+
+	ordered := slices.Clone(s.Remaining)
+	for i := len(s.Eliminated) - 1; i >= 0; i-- {
+		ordered = append(ordered, s.Eliminated[i]...)
+	}
+
+	placements := make([]Placement, len(ordered))
+	for i, player := range ordered {
+		placements[i] = Placement{Position: i + 1, Player: player}
+	}
+	return placements
+}
+
+func (s *SoloFFAHeatsState) GetNextMatches() []Match {
+	if s.IsComplete {
+		return []Match{}
+	}
+
+	var nextMatches []Match
+	for _, match := range s.Matches {
+		if match.Round == s.CurrentRound && !match.Finished {
+			nextMatches = append(nextMatches, match)
+		}
+	}
+
+	return nextMatches
+}
+
+func (s *SoloFFAHeatsState) GetMatchHistory() []Match {
+	var history []Match
+	for _, match := range s.Matches {
+		if match.Finished {
+			history = append(history, match)
+		}
+	}
+	return history
+}
+
+func (s *SoloFFAHeatsState) GetTournamentStatus() map[string]interface{} {
+	eliminatedPlayers := []string{}
+	for _, round := range s.Eliminated {
+		eliminatedPlayers = append(eliminatedPlayers, round...)
+	}
+
+	return map[string]interface{}{
+		"tournament_id":      s.TournamentID,
+		"current_round":      s.CurrentRound,
+		"is_complete":        s.IsComplete,
+		"is_final_round":     s.isFinalRound(),
+		"winner":             s.Winner,
+		"lobby_size":         s.LobbySize,
+		"advance":            s.Advance,
+		"active_players":     s.Remaining,
+		"eliminated_players": eliminatedPlayers,
+		"placements":         s.GetPlacements(),
+		"next_matches":       s.GetNextMatches(),
+	}
+}
+
+func (s *SoloFFAHeatsState) IsFinished() bool {
+	return s.IsComplete
+}
+
+func (s *SoloFFAHeatsState) GetWinner() string {
+	return s.Winner
+}
+
+func (s *SoloFFAHeatsState) GetBracketVisualization() string {
+	result := fmt.Sprintf("Tournament: %s\n", s.TournamentID)
+	result += fmt.Sprintf("Current Round: %d\n", s.CurrentRound)
+	result += fmt.Sprintf("Status: %s\n", func() string {
+		if s.IsComplete {
+			return fmt.Sprintf("COMPLETE - Winner: %s", s.Winner)
+		}
+		return "IN PROGRESS"
+	}())
+	result += "\n"
+
+	for round := 1; round <= s.CurrentRound; round++ {
+		result += fmt.Sprintf("=== Round %d ===\n", round)
+
+		for _, match := range s.Matches {
+			if match.Round != round {
+				continue
+			}
+
+			if !match.Finished {
+				result += fmt.Sprintf("  %s: %s - PENDING\n", match.ID, strings.Join(match.Players, ", "))
+				continue
+			}
+
+			finishers := []string{}
+			for _, res := range s.heatResults(match.ID) {
+				finishers = append(finishers, fmt.Sprintf("%d. %s", res.Position, res.Player))
+			}
+			result += fmt.Sprintf("  %s: %s\n", match.ID, strings.Join(finishers, ", "))
+		}
+
+		result += "\n"
+	}
+
+	return result
+}
+
+// GetBracket returns the heats of every round with each player's finishing
+// position in their heat.
+func (s *SoloFFAHeatsState) GetBracket() Bracket {
+	bracket := buildBracket(s.TournamentID, s.Players, s.Matches, nil, true)
+	bracket.Complete = s.IsComplete
+	bracket.Winner = s.Winner
+
+	for _, round := range bracket.Rounds {
+		for _, match := range round.Matches {
+			for i := range match.Slots {
+				for _, result := range s.heatResults(match.ID) {
+					if result.Player == match.Slots[i].Player {
+						match.Slots[i].Position = result.Position
+					}
+				}
+			}
+		}
+	}
+
+	return bracket
+}
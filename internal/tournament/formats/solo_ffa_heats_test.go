@@ -0,0 +1,89 @@
+package formats_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"tournament-manager/internal/tournament/formats"
+)
+
+func TestFFAHeats(t *testing.T) {
+	players := []string{"senez", "kha0x", "i77_", "tauktes", "yaweee", "chapa", "dulci", "mekkro", "vwaz", "lunar"}
+	s := formats.NewSoloFFAHeatsState(t.Context(), "id", players, 4, 2)
+
+	expectedHeats := []int{3, 2, 1}
+	for round, heats := range expectedHeats {
+		m := s.GetNextMatches()
+		if len(m) != heats {
+			t.Fatalf("unexpected number of heats in round %v, expected %v, got %v", round+1, heats, len(m))
+		}
+
+		for _, match := range m {
+			if len(match.Players) > 4 {
+				t.Errorf("heat %v has %v players, expected at most %v", match.ID, len(match.Players), 4)
+			}
+
+			// Players finish in the order they are listed.
+			times := make([]uint64, len(match.Players))
+			for i := range times {
+				times[i] = 120000 + uint64(i)*1000
+			}
+
+			if err := s.HandleGameResult(t.Context(), match.ID, match.Players, times); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	if !s.IsFinished() {
+		t.Fatalf("expected the tournament to be finished after the final heat")
+	}
+
+	final := s.GetMatchHistory()[len(s.GetMatchHistory())-1]
+	if s.GetWinner() != final.Players[0] {
+		t.Errorf("unexpected winner, expected %v, got %v", final.Players[0], s.GetWinner())
+	}
+
+	placements := s.GetPlacements()
+	if len(placements) != len(players) {
+		t.Fatalf("unexpected number of placements, expected %v, got %v", len(players), len(placements))
+	}
+
+	for i, player := range final.Players {
+		if placements[i].Player != player {
+			t.Errorf("unexpected placement %v, expected %v, got %v", i+1, player, placements[i].Player)
+		}
+	}
+
+	fmt.Println(s.GetBracketVisualization())
+}
+
+func TestFFAHeatsRejectsWrongPlayers(t *testing.T) {
+	s := formats.NewSoloFFAHeatsState(t.Context(), "id", []string{"senez", "kha0x", "i77_", "tauktes"}, 4, 2)
+
+	m := s.GetNextMatches()
+	if err := s.HandleGameResult(t.Context(), m[0].ID, []string{"senez", "kha0x"}, []uint64{120000, 135000}); err == nil {
+		t.Errorf("expected an error for a result missing players")
+	}
+}
+
+func TestFFAHeatsAdvancesLonePlayer(t *testing.T) {
+	s := formats.NewSoloFFAHeatsState(t.Context(), "id", []string{"senez", "kha0x", "i77_"}, 2, 1)
+
+	m := s.GetNextMatches()
+	if len(m) != 1 || len(m[0].Players) != 2 {
+		t.Fatalf("unexpected heats in round 1, expected a single heat of 2, got %v", m)
+	}
+
+	if err := s.HandleGameResult(t.Context(), m[0].ID, m[0].Players, []uint64{120000, 135000}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Contains(s.Remaining, "senez") {
+		t.Errorf("expected the player without an opponent to advance, got %v", s.Remaining)
+	}
+
+	if _, err := formats.New(t.Context(), "solo_ffa_heats", "id", []string{"senez", "kha0x", "i77_"}, formats.Options{LobbySize: 2}); err == nil {
+		t.Errorf("expected lobbies of 2 to be rejected")
+	}
+}
@@ -0,0 +1,328 @@
+package formats
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+// SoloRoundRobinState schedules every pairing up front with the circle method.
+// Matches can be reported in any order, rounds only group them for display.
+type SoloRoundRobinState struct {
+	TournamentID string
+	Players      []string
+	Matches      []Match
+	Byes         []Bye
+	TotalRounds  int
+	Tiebreakers  []string
+	IsComplete   bool
+	Winner       string
+	BestOf       []int
+	// PlayerStatus only holds the players who left the tournament.
+	PlayerStatus map[string]PlayerStatus `json:",omitempty"`
+	Scoreboard
+}
+
+func init() {
+	Register("solo_round_robin", "Solo Round Robin", func(ctx context.Context, tournamentID string, players []string, opts Options) (Format, error) {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
+
+		state := NewSoloRoundRobinState(ctx, tournamentID, players, opts.tiebreakers(TiebreakHeadToHead, TiebreakTotalTime, TiebreakBestTime), opts.BestOf...)
+		if state == nil {
+			return nil, fmt.Errorf("failed to create tournament state")
+		}
+		return state, nil
+	}, func() Format { return &SoloRoundRobinState{} })
+}
+
+// NewSoloRoundRobinState schedules a round robin. bestOf sets the series
+// length per round, see Options.BestOf.
+func NewSoloRoundRobinState(ctx context.Context, tournamentID string, players []string, tiebreakers []string, bestOf ...int) *SoloRoundRobinState {
+	if len(players) < 2 {
+		slog.WarnContext(ctx, "Not enough players for round robin", "count", len(players))
+		return nil
+	}
+
+	state := &SoloRoundRobinState{
+		TournamentID: tournamentID,
+		Players:      players,
+		Matches:      []Match{},
+		Byes:         []Bye{},
+		Tiebreakers:  tiebreakers,
+		BestOf:       bestOf,
+		Scoreboard:   newScoreboard(),
+	}
+
+	state.generateSchedule(ctx)
+
+	return state
+}
+
+// generateSchedule uses the circle method: the first player stays in place
+// while everyone else rotates one seat per round. An empty seat is added for
+// odd player counts and whoever faces it gets a bye.
+func (s *SoloRoundRobinState) generateSchedule(ctx context.Context) {
+	seats := slices.Clone(s.Players)
+	if len(seats)%2 == 1 {
+		seats = append(seats, "")
+	}
+
+	s.TotalRounds = len(seats) - 1
+	nextMatchID := 1
+
+	for round := 1; round <= s.TotalRounds; round++ {
+		for i := 0; i < len(seats)/2; i++ {
+			player1, player2 := seats[i], seats[len(seats)-1-i]
+
+			if player1 == "" || player2 == "" {
+				s.Byes = append(s.Byes, Bye{Round: round, Player: player1 + player2})
+				continue
+			}
+
+			s.Matches = append(s.Matches, Match{
+				ID:       fmt.Sprintf("match_%d", nextMatchID),
+				Round:    round,
+				Player1:  player1,
+				Player2:  player2,
+				BestOf:   seriesLength(s.BestOf, round),
+				Winner:   "",
+				Finished: false,
+			})
+			nextMatchID++
+		}
+
+		last := seats[len(seats)-1]
+		copy(seats[2:], seats[1:len(seats)-1])
+		seats[1] = last
+	}
+
+	slog.DebugContext(ctx, "Generated round robin schedule", "rounds", s.TotalRounds, "matches", len(s.Matches))
+}
+
+func (s *SoloRoundRobinState) HandleGameResult(ctx context.Context, gameID string, players []string, times []uint64) error {
+	if len(players) != len(times) {
+		return fmt.Errorf("players and times arrays must have the same length")
+	}
+
+	match, err := findMatch(s.Matches, gameID, players)
+	if err != nil {
+		return err
+	}
+
+	if len(players) != 2 || !slices.Contains(players, match.Player1) || !slices.Contains(players, match.Player2) {
+		return fmt.Errorf("match %s is %s vs %s, got result for %v", match.ID, match.Player1, match.Player2, players)
+	}
+
+	winnerIndex := 0
+	for i, time := range times {
+		if time < times[winnerIndex] {
+			winnerIndex = i
+		}
+	}
+
+	if err := match.recordGame(gameID, players[winnerIndex]); err != nil {
+		return err
+	}
+
+	for i, player := range players {
+		s.recordTime(player, times[i])
+	}
+
+	if !match.Finished {
+		slog.InfoContext(ctx, "Game result processed", "game_id", gameID, "winner", players[winnerIndex], "wins", match.Wins)
+		return nil
+	}
+
+	s.finishMatch(ctx, match)
+
+	return nil
+}
+
+// Forfeit gives the opponent of player a walkover in the player's next
+// unfinished match. Players leaving the tournament forfeit all of their
+// unfinished matches, but keep their place in the standings.
+func (s *SoloRoundRobinState) Forfeit(ctx context.Context, player string, status PlayerStatus) error {
+	if err := checkForfeit(s.Players, s.PlayerStatus, player, status); err != nil {
+		return err
+	}
+
+	forfeited := 0
+	for i := range s.Matches {
+		match := &s.Matches[i]
+		if match.Finished || (match.Player1 != player && match.Player2 != player) {
+			continue
+		}
+
+		match.walkover(match.opponent(player))
+		s.finishMatch(ctx, match)
+		forfeited++
+
+		if status == StatusForfeited {
+			break
+		}
+	}
+
+	if forfeited == 0 {
+		return fmt.Errorf("player %s has no unfinished match to forfeit", player)
+	}
+
+	if status.hasLeft() {
+		if s.PlayerStatus == nil {
+			s.PlayerStatus = make(map[string]PlayerStatus)
+		}
+		s.PlayerStatus[player] = status
+	}
+
+	slog.InfoContext(ctx, "Player forfeited", "player", player, "status", status, "matches", forfeited)
+	return nil
+}
+
+// finishMatch counts a finished match and completes the tournament once
+// every match has been played.
+func (s *SoloRoundRobinState) finishMatch(ctx context.Context, match *Match) {
+	for _, player := range match.Participants() {
+		s.recordMatch(player, player == match.Winner)
+	}
+
+	slog.InfoContext(ctx, "Match result processed", "match_id", match.ID, "winner", match.Winner, "walkover", match.Walkover)
+
+	if len(s.GetMatchHistory()) == len(s.Matches) {
+		s.Winner = s.GetStandings()[0].Player
+		s.IsComplete = true
+		slog.InfoContext(ctx, "Tournament complete", "winner", s.Winner)
+	}
+}
+
+// GetStandings ranks players by wins, breaking ties with the configured
+// tiebreakers in order and falling back to the original player order.
+func (s *SoloRoundRobinState) GetStandings() []Standing {
+	ordered := s.rank(s.Players, s.Matches, s.Tiebreakers, func(tiebreaker string, group []string) func(string) int64 {
+		if tiebreaker != TiebreakHeadToHead {
+			return nil
+		}
+
+		wins := headToHeadWins(s.Matches, group)
+		return func(player string) int64 { return -int64(wins[player]) }
+	})
+
+	return s.standings(ordered)
+}
+
+func (s *SoloRoundRobinState) currentRound() int {
+	for _, match := range s.Matches {
+		if !match.Finished {
+			return match.Round
+		}
+	}
+	return s.TotalRounds
+}
+
+func (s *SoloRoundRobinState) GetNextMatches() []Match {
+	if s.IsComplete {
+		return []Match{}
+	}
+
+	round := s.currentRound()
+
+	var nextMatches []Match
+	for _, match := range s.Matches {
+		if match.Round == round && !match.Finished {
+			nextMatches = append(nextMatches, match)
+		}
+	}
+
+	return nextMatches
+}
+
+func (s *SoloRoundRobinState) GetMatchHistory() []Match {
+	var history []Match
+	for _, match := range s.Matches {
+		if match.Finished {
+			history = append(history, match)
+		}
+	}
+	return history
+}
+
+func (s *SoloRoundRobinState) GetTournamentStatus() map[string]interface{} {
+	return map[string]interface{}{
+		"tournament_id":  s.TournamentID,
+		"current_round":  s.currentRound(),
+		"total_rounds":   s.TotalRounds,
+		"is_complete":    s.IsComplete,
+		"winner":         s.Winner,
+		"matches_played": len(s.GetMatchHistory()),
+		"matches_total":  len(s.Matches),
+		"tiebreakers":    s.Tiebreakers,
+		"standings":      s.GetStandings(),
+		"next_matches":   s.GetNextMatches(),
+	}
+}
+
+func (s *SoloRoundRobinState) IsFinished() bool {
+	return s.IsComplete
+}
+
+func (s *SoloRoundRobinState) GetWinner() string {
+	return s.Winner
+}
+
+func (s *SoloRoundRobinState) GetPlacements() []Placement {
+	if !s.IsComplete {
+		return []Placement{}
+	}
+	return standingsPlacements(s.GetStandings())
+}
+
+func (s *SoloRoundRobinState) GetBracketVisualization() string {
+	result := fmt.Sprintf("Tournament: %s\n", s.TournamentID)
+	result += fmt.Sprintf("Matches Played: %d/%d\n", len(s.GetMatchHistory()), len(s.Matches))
+	result += fmt.Sprintf("Status: %s\n", func() string {
+		if s.IsComplete {
+			return fmt.Sprintf("COMPLETE - Winner: %s", s.Winner)
+		}
+		return "IN PROGRESS"
+	}())
+	result += "\n"
+
+	for round := 1; round <= s.TotalRounds; round++ {
+		result += fmt.Sprintf("=== Round %d ===\n", round)
+
+		for _, match := range s.Matches {
+			if match.Round != round {
+				continue
+			}
+
+			status := "PENDING"
+			if match.Finished {
+				status = fmt.Sprintf("WINNER: %s", match.Winner)
+			}
+			result += fmt.Sprintf("  %s vs %s - %s%s\n", match.Player1, match.Player2, status, match.seriesScore())
+		}
+
+		for _, bye := range s.Byes {
+			if bye.Round == round {
+				result += fmt.Sprintf("  %s - BYE\n", bye.Player)
+			}
+		}
+
+		result += "\n"
+	}
+
+	result += "=== Standings ===\n"
+	for _, standing := range s.GetStandings() {
+		result += fmt.Sprintf("  %d. %s - %dW %dL\n", standing.Rank, standing.Player, standing.Wins, standing.Losses)
+	}
+
+	return result
+}
+
+func (s *SoloRoundRobinState) GetBracket() Bracket {
+	bracket := buildBracket(s.TournamentID, s.Players, s.Matches, s.Byes, false)
+	bracket.Complete = s.IsComplete
+	bracket.Winner = s.Winner
+	return bracket
+}
@@ -0,0 +1,125 @@
+package formats_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"tournament-manager/internal/tournament/formats"
+)
+
+func TestRoundRobinSchedule(t *testing.T) {
+	players := []string{"senez", "kha0x", "i77_", "tauktes", "yaweee"}
+	s := formats.NewSoloRoundRobinState(t.Context(), "id", players, []string{formats.TiebreakHeadToHead})
+
+	if s.TotalRounds != 5 {
+		t.Errorf("unexpected number of rounds, expected %v, got %v", 5, s.TotalRounds)
+	}
+
+	if len(s.Matches) != 10 {
+		t.Errorf("unexpected number of matches, expected %v, got %v", 10, len(s.Matches))
+	}
+
+	if len(s.Byes) != 5 {
+		t.Errorf("unexpected number of byes, expected %v, got %v", 5, len(s.Byes))
+	}
+
+	pairings := make(map[string]int)
+	for _, match := range s.Matches {
+		if match.Player1 < match.Player2 {
+			pairings[match.Player1+" "+match.Player2]++
+		} else {
+			pairings[match.Player2+" "+match.Player1]++
+		}
+	}
+
+	for pairing, count := range pairings {
+		if count != 1 {
+			t.Errorf("pairing %v scheduled %v times", pairing, count)
+		}
+	}
+}
+
+func TestRoundRobinTiebreakers(t *testing.T) {
+	// senez, kha0x and i77_ each win once, so head to head can't separate
+	// them and total time decides.
+	results := map[string]map[string]uint64{
+		"senez": {"kha0x": 120000, "i77_": 140000},
+		"kha0x": {"i77_": 118000, "senez": 125000},
+		"i77_":  {"senez": 119000, "kha0x": 130000},
+	}
+
+	s := formats.NewSoloRoundRobinState(t.Context(), "id", []string{"senez", "kha0x", "i77_"}, []string{
+		formats.TiebreakHeadToHead,
+		formats.TiebreakTotalTime,
+	})
+
+	for !s.IsFinished() {
+		for _, match := range s.GetNextMatches() {
+			times := []uint64{results[match.Player1][match.Player2], results[match.Player2][match.Player1]}
+			if err := s.HandleGameResult(t.Context(), match.ID, []string{match.Player1, match.Player2}, times); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	standings := s.GetStandings()
+	expected := []string{"kha0x", "i77_", "senez"}
+	for i, player := range expected {
+		if standings[i].Player != player {
+			t.Errorf("unexpected standing at rank %v, expected %v, got %v", i+1, player, standings[i].Player)
+		}
+	}
+
+	if s.GetWinner() != "kha0x" {
+		t.Errorf("unexpected winner, expected %v, got %v", "kha0x", s.GetWinner())
+	}
+
+	fmt.Println(s.GetBracketVisualization())
+}
+
+func TestRoundRobinTotalTimeIgnoresWalkovers(t *testing.T) {
+	// tauktes loses to senez and withdraws, handing kha0x and i77_ walkovers.
+	// The other three beat each other once, so all of them have two wins,
+	// and senez, who raced one game more, is the fastest per game.
+	results := map[string]map[string]uint64{
+		"senez":   {"tauktes": 110000, "kha0x": 110000, "i77_": 111000},
+		"kha0x":   {"senez": 115000, "i77_": 112000},
+		"i77_":    {"kha0x": 116000, "senez": 109000},
+		"tauktes": {"senez": 130000},
+	}
+
+	s := formats.NewSoloRoundRobinState(t.Context(), "id", []string{"senez", "kha0x", "i77_", "tauktes"}, []string{formats.TiebreakTotalTime})
+
+	report := func(match formats.Match) {
+		t.Helper()
+
+		times := []uint64{results[match.Player1][match.Player2], results[match.Player2][match.Player1]}
+		if err := s.HandleGameResult(t.Context(), match.ID, []string{match.Player1, match.Player2}, times); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	for _, match := range s.Matches {
+		if slices.Contains(match.Participants(), "senez") && slices.Contains(match.Participants(), "tauktes") {
+			report(match)
+		}
+	}
+
+	if err := s.Forfeit(t.Context(), "tauktes", formats.StatusWithdrawn); err != nil {
+		t.Fatalf("failed to withdraw: %v", err)
+	}
+
+	for !s.IsFinished() {
+		for _, match := range s.GetNextMatches() {
+			report(match)
+		}
+	}
+
+	standings := s.GetStandings()
+	expected := []string{"senez", "i77_", "kha0x", "tauktes"}
+	for i, player := range expected {
+		if standings[i].Player != player {
+			t.Errorf("unexpected standing at rank %v, expected %v, got %v", i+1, player, standings[i].Player)
+		}
+	}
+}
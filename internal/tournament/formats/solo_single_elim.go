@@ -1,45 +1,47 @@
 package formats
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"math"
 )
 
-type PlayerStatus int
-
-const (
-	StatusActive PlayerStatus = iota
-	StatusEliminated
-	StatusBye
-	StatusWinner
-)
-
-type Match struct {
-	ID       string
-	Round    int
-	Player1  string
-	Player2  string
-	Winner   string
-	Finished bool
-}
-
 type SoloSingleElimState struct {
 	TournamentID string
 	Players      []string
 	PlayerStatus map[string]PlayerStatus
 	Matches      []Match
+	Byes         []Bye
 	CurrentRound int
 	TotalRounds  int
 	IsComplete   bool
 	Winner       string
 	NextMatchID  int
 	RoundWinners [][]string
+	BestOf       []int
+}
+
+func init() {
+	Register("solo_single_elim", "Solo Single Elimination", func(ctx context.Context, tournamentID string, players []string, opts Options) (Format, error) {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
+
+		state := NewSoloSingleElimState(ctx, tournamentID, players, opts.BestOf...)
+		if state == nil {
+			return nil, fmt.Errorf("failed to create tournament state")
+		}
+		return state, nil
+	}, func() Format { return &SoloSingleElimState{} })
 }
 
-func NewSoloSingleElimState(tournamentID string, players []string) *SoloSingleElimState {
+// NewSoloSingleElimState starts a single elimination bracket with players
+// given in seed order. bestOf sets the series length per round, see
+// Options.BestOf.
+func NewSoloSingleElimState(ctx context.Context, tournamentID string, players []string, bestOf ...int) *SoloSingleElimState {
 	if len(players) < 2 {
-		slog.Warn("Not enough players for single elimination", "count", len(players))
+		slog.WarnContext(ctx, "Not enough players for single elimination", "count", len(players))
 		return nil
 	}
 
@@ -55,58 +57,57 @@ func NewSoloSingleElimState(tournamentID string, players []string) *SoloSingleEl
 		Players:      players,
 		PlayerStatus: playerStatus,
 		Matches:      []Match{},
+		Byes:         []Bye{},
 		CurrentRound: 1,
 		TotalRounds:  totalRounds,
 		IsComplete:   false,
 		Winner:       "",
 		NextMatchID:  1,
 		RoundWinners: make([][]string, totalRounds),
+		BestOf:       bestOf,
 	}
 
-	state.generateRoundMatches()
+	state.generateRoundMatches(ctx)
 
 	return state
 }
 
-func (s *SoloSingleElimState) generateRoundMatches() {
+// generateRoundMatches pairs neighbouring players of the previous round's
+// winners. The first round uses the seeded slots, so byes only happen there
+// and every later round has a power of two players left.
+func (s *SoloSingleElimState) generateRoundMatches(ctx context.Context) {
 	var activePlayers []string
 
 	if s.CurrentRound == 1 {
-		activePlayers = make([]string, len(s.Players))
-		copy(activePlayers, s.Players)
+		activePlayers = seedSlots(s.Players)
 	} else {
 		activePlayers = s.RoundWinners[s.CurrentRound-2]
-
-		for player, status := range s.PlayerStatus {
-			if status == StatusBye {
-				activePlayers = append(activePlayers, player)
-				s.PlayerStatus[player] = StatusActive
-			}
-		}
 	}
 
-	slog.Debug("Generating matches for round", "round", s.CurrentRound, "active_players", len(activePlayers))
+	slog.DebugContext(ctx, "Generating matches for round", "round", s.CurrentRound, "active_players", len(activePlayers))
 
-	if len(activePlayers)%2 == 1 {
-		byePlayer := activePlayers[len(activePlayers)-1]
-		s.PlayerStatus[byePlayer] = StatusBye
-		activePlayers = activePlayers[:len(activePlayers)-1]
-		slog.Debug("Player gets bye", "player", byePlayer, "round", s.CurrentRound)
-	}
+	for i := 0; i+1 < len(activePlayers); i += 2 {
+		if activePlayers[i] == "" || activePlayers[i+1] == "" {
+			byePlayer := activePlayers[i] + activePlayers[i+1]
+			s.PlayerStatus[byePlayer] = StatusBye
+			s.Byes = append(s.Byes, Bye{Round: s.CurrentRound, Player: byePlayer})
+			slog.DebugContext(ctx, "Player gets bye", "player", byePlayer, "round", s.CurrentRound)
+			continue
+		}
 
-	for i := 0; i < len(activePlayers); i += 2 {
 		match := Match{
 			ID:       fmt.Sprintf("match_%d", s.NextMatchID),
 			Round:    s.CurrentRound,
 			Player1:  activePlayers[i],
 			Player2:  activePlayers[i+1],
+			BestOf:   seriesLength(s.BestOf, s.CurrentRound),
 			Winner:   "",
 			Finished: false,
 		}
 		s.Matches = append(s.Matches, match)
 		s.NextMatchID++
 
-		slog.Debug("Created match", "match_id", match.ID, "player1", match.Player1, "player2", match.Player2)
+		slog.DebugContext(ctx, "Created match", "match_id", match.ID, "player1", match.Player1, "player2", match.Player2)
 	}
 }
 
@@ -137,27 +138,14 @@ func (s *SoloSingleElimState) getPlayersWithBye() []string {
 	return byePlayers
 }
 
-func (s *SoloSingleElimState) HandleGameResult(gameID string, players []string, times []uint64) error {
+func (s *SoloSingleElimState) HandleGameResult(ctx context.Context, gameID string, players []string, times []uint64) error {
 	if len(players) != len(times) {
 		return fmt.Errorf("players and times arrays must have the same length")
 	}
 
-	matchIndex := -1
-	for i, match := range s.Matches {
-		if match.ID == gameID {
-			matchIndex = i
-			break
-		}
-	}
-
-	if matchIndex == -1 {
-		return fmt.Errorf("match not found: %s", gameID)
-	}
-
-	match := &s.Matches[matchIndex]
-
-	if match.Finished {
-		return fmt.Errorf("match already finished: %s", gameID)
+	match, err := findMatch(s.Matches, gameID, players)
+	if err != nil {
+		return err
 	}
 
 	winnerIndex := 0
@@ -168,25 +156,76 @@ func (s *SoloSingleElimState) HandleGameResult(gameID string, players []string,
 	}
 
 	winner := players[winnerIndex]
-	slog.Info("setting winner to ", "winner", winner)
-	match.Winner = winner
-	match.Finished = true
+	if err := match.recordGame(gameID, winner); err != nil {
+		return err
+	}
 
-	for _, player := range players {
-		if player != winner {
-			s.PlayerStatus[player] = StatusEliminated
-		}
+	if !match.Finished {
+		slog.InfoContext(ctx, "Game result processed", "game_id", gameID, "winner", winner, "wins", match.Wins)
+		return nil
 	}
 
-	slog.Info("Match result processed", "match_id", gameID, "winner", winner)
+	s.finishMatch(ctx, match)
+	s.resolveWalkovers(ctx)
 
-	if s.isRoundComplete() {
-		s.advanceToNextRound()
+	return nil
+}
+
+// Forfeit knocks player out by giving their opponent a walkover. Players
+// leaving the tournament while waiting on a bye forfeit the match they are
+// paired into next.
+func (s *SoloSingleElimState) Forfeit(ctx context.Context, player string, status PlayerStatus) error {
+	if err := checkForfeit(s.Players, s.PlayerStatus, player, status); err != nil {
+		return err
+	}
+
+	match := pendingMatch(s.Matches, s.CurrentRound, player)
+	if match == nil && status == StatusForfeited {
+		return fmt.Errorf("player %s has no pending match to forfeit", player)
 	}
 
+	if match != nil {
+		match.walkover(match.opponent(player))
+		s.finishMatch(ctx, match)
+	}
+
+	s.PlayerStatus[player] = status
+	slog.InfoContext(ctx, "Player forfeited", "player", player, "status", status)
+
+	s.resolveWalkovers(ctx)
 	return nil
 }
 
+// finishMatch knocks out the loser of a finished match and advances once the
+// round is over.
+func (s *SoloSingleElimState) finishMatch(ctx context.Context, match *Match) {
+	loser := match.opponent(match.Winner)
+	if !s.PlayerStatus[loser].hasLeft() {
+		s.PlayerStatus[loser] = StatusEliminated
+	}
+
+	slog.InfoContext(ctx, "Match result processed", "match_id", match.ID, "winner", match.Winner, "walkover", match.Walkover)
+
+	if s.isRoundComplete() {
+		s.advanceToNextRound(ctx)
+	}
+}
+
+// resolveWalkovers finishes the pending matches of players who have left the
+// tournament, including the ones created by advancing.
+func (s *SoloSingleElimState) resolveWalkovers(ctx context.Context) {
+	for i := 0; i < len(s.Matches); i++ {
+		if s.Matches[i].Finished {
+			continue
+		}
+
+		if winner, ok := walkoverWinner(s.Matches[i], s.PlayerStatus); ok {
+			s.Matches[i].walkover(winner)
+			s.finishMatch(ctx, &s.Matches[i])
+		}
+	}
+}
+
 func (s *SoloSingleElimState) isRoundComplete() bool {
 	for _, match := range s.Matches {
 		if match.Round == s.CurrentRound && !match.Finished {
@@ -196,17 +235,21 @@ func (s *SoloSingleElimState) isRoundComplete() bool {
 	return true
 }
 
-func (s *SoloSingleElimState) advanceToNextRound() {
+func (s *SoloSingleElimState) advanceToNextRound(ctx context.Context) {
 	var roundWinners []string
-	for _, match := range s.Matches {
-		if match.Round == s.CurrentRound && match.Finished {
-			roundWinners = append(roundWinners, match.Winner)
+	if s.CurrentRound == 1 {
+		roundWinners = slotWinners(seedSlots(s.Players), s.Matches)
+	} else {
+		for _, match := range s.Matches {
+			if match.Round == s.CurrentRound && match.Finished {
+				roundWinners = append(roundWinners, match.Winner)
+			}
 		}
 	}
 
 	for player, status := range s.PlayerStatus {
 		if status == StatusBye {
-			roundWinners = append(roundWinners, player)
+			s.PlayerStatus[player] = StatusActive
 		}
 	}
 
@@ -216,18 +259,18 @@ func (s *SoloSingleElimState) advanceToNextRound() {
 		s.Winner = roundWinners[0]
 		s.PlayerStatus[s.Winner] = StatusWinner
 		s.IsComplete = true
-		slog.Info("Tournament complete", "winner", s.Winner)
+		slog.InfoContext(ctx, "Tournament complete", "winner", s.Winner)
 		return
 	}
 
 	s.CurrentRound++
 	if s.CurrentRound > s.TotalRounds {
-		slog.Warn("Tournament exceeded expected rounds", "current", s.CurrentRound, "expected", s.TotalRounds)
+		slog.WarnContext(ctx, "Tournament exceeded expected rounds", "current", s.CurrentRound, "expected", s.TotalRounds)
 	}
 
-	slog.Info("Advancing to next round", "round", s.CurrentRound, "remaining_players", len(roundWinners))
+	slog.InfoContext(ctx, "Advancing to next round", "round", s.CurrentRound, "remaining_players", len(roundWinners))
 
-	s.generateRoundMatches()
+	s.generateRoundMatches(ctx)
 }
 
 func (s *SoloSingleElimState) GetNextMatches() []Match {
@@ -304,21 +347,43 @@ func (s *SoloSingleElimState) GetBracketVisualization() string {
 			if match.Finished {
 				status = fmt.Sprintf("WINNER: %s", match.Winner)
 			}
-			result += fmt.Sprintf("  %s vs %s - %s\n", match.Player1, match.Player2, status)
+			result += fmt.Sprintf("  %s vs %s - %s%s\n", match.Player1, match.Player2, status, match.seriesScore())
 		}
 
-		byePlayers := []string{}
-		for player, status := range s.PlayerStatus {
-			if status == StatusBye {
-				byePlayers = append(byePlayers, player)
+		for _, bye := range s.Byes {
+			if bye.Round == round {
+				result += fmt.Sprintf("  %s - BYE\n", bye.Player)
 			}
 		}
-		for _, player := range byePlayers {
-			result += fmt.Sprintf("  %s - BYE\n", player)
-		}
 
 		result += "\n"
 	}
 
 	return result
 }
+
+func (s *SoloSingleElimState) IsFinished() bool {
+	return s.IsComplete
+}
+
+func (s *SoloSingleElimState) GetWinner() string {
+	return s.Winner
+}
+
+// GetPlacements ranks players by the round they were knocked out in, once the
+// tournament is complete.
+func (s *SoloSingleElimState) GetPlacements() []Placement {
+	if !s.IsComplete {
+		return []Placement{}
+	}
+	return placementsByElimination(s.Winner, s.Matches)
+}
+
+// GetBracket returns the bracket with every slot linked to the match its
+// player won to get there.
+func (s *SoloSingleElimState) GetBracket() Bracket {
+	bracket := buildBracket(s.TournamentID, s.Players, s.Matches, s.Byes, true)
+	bracket.Complete = s.IsComplete
+	bracket.Winner = s.Winner
+	return bracket
+}
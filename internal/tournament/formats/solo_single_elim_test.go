@@ -8,11 +8,11 @@ import (
 )
 
 func TestSingleElim(t *testing.T) {
-	s := formats.NewSoloSingleElimState("id", []string{
+	s := formats.NewSoloSingleElimState(t.Context(), "id", []string{
 		"senez",
-		"kha0x",
 		"i77_",
 		"tauktes",
+		"kha0x",
 	})
 
 	slog.Debug("current status: ", "status", s.GetTournamentStatus())
@@ -27,14 +27,14 @@ func TestSingleElim(t *testing.T) {
 
 	fmt.Println(s.GetBracketVisualization())
 
-	s.HandleGameResult("match_1", []string{"senez", "kha0x"}, []uint64{135000, 120000})
+	s.HandleGameResult(t.Context(), "match_1", []string{"senez", "kha0x"}, []uint64{135000, 120000})
 	if s.Matches[0].Winner != "kha0x" {
 		t.Errorf("unexpected winner, expected %v, got %v", "kha0x", m[0].Winner)
 	}
 
 	fmt.Println(s.GetBracketVisualization())
 
-	s.HandleGameResult("match_2", []string{"i77_", "tauktes"}, []uint64{120000, 135000})
+	s.HandleGameResult(t.Context(), "match_2", []string{"i77_", "tauktes"}, []uint64{120000, 135000})
 	if s.Matches[1].Winner != "i77_" {
 		t.Errorf("unexpected winner, expected %v, got %v", "tauktes", m[1].Winner)
 	}
@@ -50,7 +50,7 @@ func TestSingleElim(t *testing.T) {
 		t.Errorf("unexpected matchup, expected %v vs %v, got %v vs %v", "kha0x", "i77_", m[0].Player1, m[0].Player2)
 	}
 
-	s.HandleGameResult("match_3", []string{"kha0x", "i77_"}, []uint64{135000, 120000})
+	s.HandleGameResult(t.Context(), "match_3", []string{"kha0x", "i77_"}, []uint64{135000, 120000})
 	if s.Matches[2].Winner != "i77_" {
 		t.Errorf("unexpected winner, expected %v, got %v", "i77_", m[0].Winner)
 	}
@@ -59,3 +59,134 @@ func TestSingleElim(t *testing.T) {
 
 	slog.Info("history: ", "history", s.GetMatchHistory())
 }
+
+func TestSingleElimBestOf(t *testing.T) {
+	s := formats.NewSoloSingleElimState(t.Context(), "id", []string{
+		"senez",
+		"i77_",
+		"tauktes",
+		"kha0x",
+	}, 1, 3)
+
+	m := s.GetNextMatches()
+	if m[0].BestOf != 1 {
+		t.Errorf("unexpected series length in round 1, expected %v, got %v", 1, m[0].BestOf)
+	}
+
+	s.HandleGameResult(t.Context(), "match_1", []string{"senez", "kha0x"}, []uint64{135000, 120000})
+	s.HandleGameResult(t.Context(), "match_2", []string{"i77_", "tauktes"}, []uint64{120000, 135000})
+
+	m = s.GetNextMatches()
+	if len(m) != 1 || m[0].BestOf != 3 {
+		t.Fatalf("unexpected final, expected a Bo3, got %v", m)
+	}
+
+	if err := s.HandleGameResult(t.Context(), "match_3-g1", []string{"kha0x", "i77_"}, []uint64{135000, 120000}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := s.HandleGameResult(t.Context(), "match_3-g3", []string{"kha0x", "i77_"}, []uint64{120000, 135000}); err == nil {
+		t.Errorf("expected an error for a game reported out of order")
+	}
+
+	s.HandleGameResult(t.Context(), "match_3-g2", []string{"kha0x", "i77_"}, []uint64{120000, 135000})
+	if s.IsFinished() {
+		t.Fatalf("expected the final to continue at 1-1")
+	}
+
+	s.HandleGameResult(t.Context(), "match_3", []string{"kha0x", "i77_"}, []uint64{120000, 135000})
+	if !s.IsFinished() || s.GetWinner() != "kha0x" {
+		t.Errorf("unexpected winner, expected %v, got %v", "kha0x", s.GetWinner())
+	}
+
+	if s.Matches[2].Wins["kha0x"] != 2 || s.Matches[2].Wins["i77_"] != 1 {
+		t.Errorf("unexpected series score, got %v", s.Matches[2].Wins)
+	}
+
+	fmt.Println(s.GetBracketVisualization())
+}
+
+func TestSingleElimSeeding(t *testing.T) {
+	s := formats.NewSoloSingleElimState(t.Context(), "id", []string{
+		"senez",
+		"kha0x",
+		"i77_",
+		"tauktes",
+		"yaweee",
+		"chapa",
+	})
+
+	m := s.GetNextMatches()
+	if len(m) != 2 {
+		t.Fatalf("unexpected number of matches, expected %v, got %v", 2, len(m))
+	}
+
+	if m[0].Player1 != "tauktes" || m[0].Player2 != "yaweee" {
+		t.Errorf("unexpected matchup, expected %v vs %v, got %v vs %v", "tauktes", "yaweee", m[0].Player1, m[0].Player2)
+	}
+
+	if m[1].Player1 != "i77_" || m[1].Player2 != "chapa" {
+		t.Errorf("unexpected matchup, expected %v vs %v, got %v vs %v", "i77_", "chapa", m[1].Player1, m[1].Player2)
+	}
+
+	byes := []string{}
+	for _, bye := range s.Byes {
+		byes = append(byes, bye.Player)
+	}
+	if len(byes) != 2 || byes[0] != "senez" || byes[1] != "kha0x" {
+		t.Errorf("unexpected byes, expected the top two seeds, got %v", byes)
+	}
+
+	s.HandleGameResult(t.Context(), m[0].ID, []string{"tauktes", "yaweee"}, []uint64{120000, 135000})
+	s.HandleGameResult(t.Context(), m[1].ID, []string{"i77_", "chapa"}, []uint64{120000, 135000})
+
+	m = s.GetNextMatches()
+	if m[0].Player1 != "senez" || m[0].Player2 != "tauktes" {
+		t.Errorf("unexpected matchup, expected %v vs %v, got %v vs %v", "senez", "tauktes", m[0].Player1, m[0].Player2)
+	}
+
+	if m[1].Player1 != "kha0x" || m[1].Player2 != "i77_" {
+		t.Errorf("unexpected matchup, expected %v vs %v, got %v vs %v", "kha0x", "i77_", m[1].Player1, m[1].Player2)
+	}
+}
+
+func TestSingleElimPlacements(t *testing.T) {
+	s := formats.NewSoloSingleElimState(t.Context(), "id", []string{"senez", "kha0x", "i77_", "tauktes", "yaweee"})
+
+	for !s.IsFinished() {
+		// The higher seed always wins.
+		m := s.GetNextMatches()[0]
+		s.HandleGameResult(t.Context(), m.ID, []string{m.Player1, m.Player2}, []uint64{120000, 135000})
+	}
+
+	expected := []formats.Placement{
+		{Position: 1, Player: "senez"},
+		{Position: 2, Player: "kha0x"},
+		{Position: 3, Player: "i77_"},
+		{Position: 3, Player: "tauktes"},
+		{Position: 5, Player: "yaweee"},
+	}
+
+	placements := s.GetPlacements()
+	if len(placements) != len(expected) {
+		t.Fatalf("unexpected number of placements, expected %v, got %v", len(expected), len(placements))
+	}
+
+	for i := range expected {
+		if placements[i] != expected[i] {
+			t.Errorf("unexpected placement, expected %v, got %v", expected[i], placements[i])
+		}
+	}
+}
+
+func TestSingleElimRejectsOtherPlayers(t *testing.T) {
+	s := formats.NewSoloSingleElimState(t.Context(), "id", []string{"senez", "i77_", "tauktes", "kha0x"})
+
+	if err := s.HandleGameResult(t.Context(), "match_1", []string{"senez", "tauktes"}, []uint64{120000, 135000}); err == nil {
+		t.Errorf("expected an error for a result from players outside the match")
+	}
+
+	if s.Matches[0].Finished {
+		t.Errorf("unexpected finished match after a rejected result")
+	}
+}
@@ -0,0 +1,473 @@
+package formats
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+)
+
+// SoloSwissState pairs players on the same score against each other every
+// round without eliminating anyone. Standings and tiebreaks are derived from
+// the recorded game results rather than kept as running totals.
+type SoloSwissState struct {
+	TournamentID string
+	Players      []string
+	Matches      []Match
+	Byes         []Bye
+	Results      []GameResult
+	CurrentRound int
+	TotalRounds  int
+	Tiebreakers  []string
+	IsComplete   bool
+	Winner       string
+	NextMatchID  int
+	BestOf       []int
+	// PlayerStatus only holds the players who left the tournament.
+	PlayerStatus map[string]PlayerStatus `json:",omitempty"`
+}
+
+func init() {
+	Register("solo_swiss", "Solo Swiss", func(ctx context.Context, tournamentID string, players []string, opts Options) (Format, error) {
+		if err := opts.Validate(); err != nil {
+			return nil, err
+		}
+
+		state := NewSoloSwissState(ctx, tournamentID, players, opts.Rounds, opts.tiebreakers(TiebreakBuchholz, TiebreakTotalTime, TiebreakBestTime), opts.BestOf...)
+		if state == nil {
+			return nil, fmt.Errorf("failed to create tournament state")
+		}
+		return state, nil
+	}, func() Format { return &SoloSwissState{} })
+}
+
+// NewSoloSwissState starts a swiss tournament. A rounds value of 0 plays
+// ceil(log2(players)) rounds, and the count is capped so nobody has to face the
+// same opponent twice. bestOf sets the series length per round, see
+// Options.BestOf.
+func NewSoloSwissState(ctx context.Context, tournamentID string, players []string, rounds int, tiebreakers []string, bestOf ...int) *SoloSwissState {
+	if len(players) < 2 {
+		slog.WarnContext(ctx, "Not enough players for swiss", "count", len(players))
+		return nil
+	}
+
+	if rounds == 0 {
+		rounds = int(math.Ceil(math.Log2(float64(len(players)))))
+	}
+	rounds = min(rounds, len(players)-1+len(players)%2)
+
+	state := &SoloSwissState{
+		TournamentID: tournamentID,
+		Players:      players,
+		Matches:      []Match{},
+		Byes:         []Bye{},
+		Results:      []GameResult{},
+		CurrentRound: 1,
+		TotalRounds:  rounds,
+		Tiebreakers:  tiebreakers,
+		NextMatchID:  1,
+		BestOf:       bestOf,
+	}
+
+	state.generateRoundMatches(ctx)
+
+	return state
+}
+
+func (s *SoloSwissState) generateRoundMatches(ctx context.Context) {
+	players := slices.DeleteFunc(s.rankPlayers(), func(player string) bool {
+		return s.PlayerStatus[player].hasLeft()
+	})
+
+	if len(players)%2 == 1 {
+		byeIndex := len(players) - 1
+		for i := len(players) - 1; i >= 0; i-- {
+			if !s.hadBye(players[i]) {
+				byeIndex = i
+				break
+			}
+		}
+
+		s.Byes = append(s.Byes, Bye{Round: s.CurrentRound, Player: players[byeIndex]})
+		slog.DebugContext(ctx, "Player gets bye", "player", players[byeIndex], "round", s.CurrentRound)
+		players = slices.Delete(players, byeIndex, byeIndex+1)
+	}
+
+	played := s.opponents()
+	budget := pairingBudget
+	pairs, ok := pairPlayers(players, played, &budget)
+	if !ok {
+		slog.WarnContext(ctx, "No pairing without rematches found, allowing rematches", "round", s.CurrentRound)
+		pairs = pairGreedily(players, played)
+	}
+
+	for _, pair := range pairs {
+		match := Match{
+			ID:       fmt.Sprintf("match_%d", s.NextMatchID),
+			Round:    s.CurrentRound,
+			Player1:  pair[0],
+			Player2:  pair[1],
+			BestOf:   seriesLength(s.BestOf, s.CurrentRound),
+			Winner:   "",
+			Finished: false,
+		}
+		s.Matches = append(s.Matches, match)
+		s.NextMatchID++
+
+		slog.DebugContext(ctx, "Created match", "match_id", match.ID, "player1", match.Player1, "player2", match.Player2)
+	}
+}
+
+// pairingBudget caps how many partial pairings pairPlayers tries per round.
+// The search is exponential in the worst case, which late rounds with few
+// rematch-free pairings left can hit, and it runs under the manager's lock.
+const pairingBudget = 10000
+
+// pairPlayers pairs players in standings order, each with the highest ranked
+// opponent left that they haven't played and that keeps the rest of the
+// round pairable. It gives up once budget runs out.
+func pairPlayers(players []string, played map[[2]string]bool, budget *int) ([][2]string, bool) {
+	if len(players) == 0 {
+		return [][2]string{}, true
+	}
+
+	*budget--
+	if *budget < 0 {
+		return nil, false
+	}
+
+	first := players[0]
+	for i := 1; i < len(players); i++ {
+		if played[[2]string{first, players[i]}] {
+			continue
+		}
+
+		rest := append(slices.Clone(players[1:i]), players[i+1:]...)
+		if pairs, ok := pairPlayers(rest, played, budget); ok {
+			return append([][2]string{{first, players[i]}}, pairs...), true
+		}
+	}
+
+	return nil, false
+}
+
+// pairGreedily pairs players in standings order, each with the highest ranked
+// opponent left they haven't played, or the next player if they have played
+// everyone left.
+func pairGreedily(players []string, played map[[2]string]bool) [][2]string {
+	players = slices.Clone(players)
+
+	var pairs [][2]string
+	for len(players) > 1 {
+		opponent := 1
+		for i := 1; i < len(players); i++ {
+			if !played[[2]string{players[0], players[i]}] {
+				opponent = i
+				break
+			}
+		}
+
+		pairs = append(pairs, [2]string{players[0], players[opponent]})
+		players = slices.Delete(players, opponent, opponent+1)[1:]
+	}
+
+	return pairs
+}
+
+// opponents returns every pairing played so far, in both orders.
+func (s *SoloSwissState) opponents() map[[2]string]bool {
+	played := make(map[[2]string]bool)
+	for _, m := range s.Matches {
+		played[[2]string{m.Player1, m.Player2}] = true
+		played[[2]string{m.Player2, m.Player1}] = true
+	}
+	return played
+}
+
+func (s *SoloSwissState) hadBye(player string) bool {
+	return slices.ContainsFunc(s.Byes, func(b Bye) bool { return b.Player == player })
+}
+
+func (s *SoloSwissState) HandleGameResult(ctx context.Context, gameID string, players []string, times []uint64) error {
+	if len(players) != len(times) {
+		return fmt.Errorf("players and times arrays must have the same length")
+	}
+
+	match, err := findMatch(s.Matches, gameID, players)
+	if err != nil {
+		return err
+	}
+
+	if len(players) != 2 || !slices.Contains(players, match.Player1) || !slices.Contains(players, match.Player2) {
+		return fmt.Errorf("match %s is %s vs %s, got result for %v", match.ID, match.Player1, match.Player2, players)
+	}
+
+	winnerIndex := 0
+	for i, time := range times {
+		if time < times[winnerIndex] {
+			winnerIndex = i
+		}
+	}
+
+	if err := match.recordGame(gameID, players[winnerIndex]); err != nil {
+		return err
+	}
+
+	for i, player := range players {
+		position := 1
+		for _, time := range times {
+			if time < times[i] {
+				position++
+			}
+		}
+		s.Results = append(s.Results, GameResult{GameID: gameID, Player: player, Position: position, Time: times[i]})
+	}
+
+	if !match.Finished {
+		slog.InfoContext(ctx, "Game result processed", "game_id", gameID, "winner", players[winnerIndex], "wins", match.Wins)
+		return nil
+	}
+
+	s.finishMatch(ctx, match)
+
+	return nil
+}
+
+// Forfeit gives the opponent of player a walkover in the current round.
+// Players leaving the tournament keep their place in the standings but
+// aren't paired again.
+func (s *SoloSwissState) Forfeit(ctx context.Context, player string, status PlayerStatus) error {
+	if err := checkForfeit(s.Players, s.PlayerStatus, player, status); err != nil {
+		return err
+	}
+
+	match := pendingMatch(s.Matches, s.CurrentRound, player)
+	if match == nil && status == StatusForfeited {
+		return fmt.Errorf("player %s has no pending match to forfeit", player)
+	}
+
+	if status.hasLeft() {
+		if s.PlayerStatus == nil {
+			s.PlayerStatus = make(map[string]PlayerStatus)
+		}
+		s.PlayerStatus[player] = status
+	}
+
+	slog.InfoContext(ctx, "Player forfeited", "player", player, "status", status)
+
+	if match != nil {
+		match.walkover(match.opponent(player))
+		s.finishMatch(ctx, match)
+	}
+
+	return nil
+}
+
+func (s *SoloSwissState) finishMatch(ctx context.Context, match *Match) {
+	slog.InfoContext(ctx, "Match result processed", "match_id", match.ID, "winner", match.Winner, "walkover", match.Walkover)
+
+	if s.isRoundComplete() {
+		s.advanceToNextRound(ctx)
+	}
+}
+
+func (s *SoloSwissState) isRoundComplete() bool {
+	for _, match := range s.Matches {
+		if match.Round == s.CurrentRound && !match.Finished {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *SoloSwissState) advanceToNextRound(ctx context.Context) {
+	if s.CurrentRound >= s.TotalRounds {
+		s.Winner = s.rankPlayers()[0]
+		s.IsComplete = true
+		slog.InfoContext(ctx, "Tournament complete", "winner", s.Winner)
+		return
+	}
+
+	s.CurrentRound++
+	slog.InfoContext(ctx, "Advancing to next round", "round", s.CurrentRound)
+
+	s.generateRoundMatches(ctx)
+
+	// With players gone a round can end up with nothing but a bye.
+	if s.isRoundComplete() {
+		s.advanceToNextRound(ctx)
+	}
+}
+
+// scoreboard rebuilds the players' records from the game results. A bye
+// counts as a win without a time.
+func (s *SoloSwissState) scoreboard() Scoreboard {
+	sb := newScoreboard()
+	for _, result := range s.Results {
+		sb.recordTime(result.Player, result.Time)
+	}
+
+	for _, match := range s.Matches {
+		if match.Finished {
+			sb.recordMatch(match.Player1, match.Winner == match.Player1)
+			sb.recordMatch(match.Player2, match.Winner == match.Player2)
+		}
+	}
+
+	for _, bye := range s.Byes {
+		sb.Wins[bye.Player]++
+	}
+
+	return sb
+}
+
+// buchholz sums the wins of every opponent a player has faced.
+func (s *SoloSwissState) buchholz(sb Scoreboard) map[string]int {
+	buchholz := make(map[string]int)
+	for _, match := range s.Matches {
+		if match.Finished {
+			buchholz[match.Player1] += sb.Wins[match.Player2]
+			buchholz[match.Player2] += sb.Wins[match.Player1]
+		}
+	}
+	return buchholz
+}
+
+func (s *SoloSwissState) rankPlayers() []string {
+	sb := s.scoreboard()
+	buchholz := s.buchholz(sb)
+
+	return sb.rank(s.Players, s.Matches, s.Tiebreakers, func(tiebreaker string, group []string) func(string) int64 {
+		switch tiebreaker {
+		case TiebreakBuchholz:
+			return func(player string) int64 { return -int64(buchholz[player]) }
+		case TiebreakHeadToHead:
+			wins := headToHeadWins(s.Matches, group)
+			return func(player string) int64 { return -int64(wins[player]) }
+		}
+		return nil
+	})
+}
+
+func (s *SoloSwissState) GetStandings() []Standing {
+	sb := s.scoreboard()
+	buchholz := s.buchholz(sb)
+
+	standings := sb.standings(s.rankPlayers())
+	for i := range standings {
+		standings[i].Buchholz = buchholz[standings[i].Player]
+	}
+	return standings
+}
+
+func (s *SoloSwissState) GetNextMatches() []Match {
+	if s.IsComplete {
+		return []Match{}
+	}
+
+	var nextMatches []Match
+	for _, match := range s.Matches {
+		if match.Round == s.CurrentRound && !match.Finished {
+			nextMatches = append(nextMatches, match)
+		}
+	}
+
+	return nextMatches
+}
+
+func (s *SoloSwissState) GetMatchHistory() []Match {
+	var history []Match
+	for _, match := range s.Matches {
+		if match.Finished {
+			history = append(history, match)
+		}
+	}
+	return history
+}
+
+func (s *SoloSwissState) GetTournamentStatus() map[string]interface{} {
+	byePlayers := []string{}
+	for _, bye := range s.Byes {
+		if bye.Round == s.CurrentRound {
+			byePlayers = append(byePlayers, bye.Player)
+		}
+	}
+
+	return map[string]interface{}{
+		"tournament_id":    s.TournamentID,
+		"current_round":    s.CurrentRound,
+		"total_rounds":     s.TotalRounds,
+		"is_complete":      s.IsComplete,
+		"winner":           s.Winner,
+		"tiebreakers":      s.Tiebreakers,
+		"players_with_bye": byePlayers,
+		"standings":        s.GetStandings(),
+		"next_matches":     s.GetNextMatches(),
+	}
+}
+
+func (s *SoloSwissState) IsFinished() bool {
+	return s.IsComplete
+}
+
+func (s *SoloSwissState) GetWinner() string {
+	return s.Winner
+}
+
+func (s *SoloSwissState) GetPlacements() []Placement {
+	if !s.IsComplete {
+		return []Placement{}
+	}
+	return standingsPlacements(s.GetStandings())
+}
+
+func (s *SoloSwissState) GetBracketVisualization() string {
+	result := fmt.Sprintf("Tournament: %s\n", s.TournamentID)
+	result += fmt.Sprintf("Current Round: %d/%d\n", s.CurrentRound, s.TotalRounds)
+	result += fmt.Sprintf("Status: %s\n", func() string {
+		if s.IsComplete {
+			return fmt.Sprintf("COMPLETE - Winner: %s", s.Winner)
+		}
+		return "IN PROGRESS"
+	}())
+	result += "\n"
+
+	for round := 1; round <= s.CurrentRound; round++ {
+		result += fmt.Sprintf("=== Round %d ===\n", round)
+
+		for _, match := range s.Matches {
+			if match.Round != round {
+				continue
+			}
+
+			status := "PENDING"
+			if match.Finished {
+				status = fmt.Sprintf("WINNER: %s", match.Winner)
+			}
+			result += fmt.Sprintf("  %s vs %s - %s%s\n", match.Player1, match.Player2, status, match.seriesScore())
+		}
+
+		for _, bye := range s.Byes {
+			if bye.Round == round {
+				result += fmt.Sprintf("  %s - BYE\n", bye.Player)
+			}
+		}
+
+		result += "\n"
+	}
+
+	result += "=== Standings ===\n"
+	for _, standing := range s.GetStandings() {
+		result += fmt.Sprintf("  %d. %s - %dW %dL (Buchholz %d)\n", standing.Rank, standing.Player, standing.Wins, standing.Losses, standing.Buchholz)
+	}
+
+	return result
+}
+
+func (s *SoloSwissState) GetBracket() Bracket {
+	bracket := buildBracket(s.TournamentID, s.Players, s.Matches, s.Byes, false)
+	bracket.Complete = s.IsComplete
+	bracket.Winner = s.Winner
+	return bracket
+}
@@ -0,0 +1,112 @@
+package formats_test
+
+import (
+	"fmt"
+	"testing"
+	"tournament-manager/internal/tournament/formats"
+)
+
+func TestSwissPairsEqualScores(t *testing.T) {
+	players := []string{"senez", "kha0x", "i77_", "tauktes", "yaweee", "chapa", "dulci", "mekkro"}
+	s := formats.NewSoloSwissState(t.Context(), "id", players, 0, []string{formats.TiebreakBuchholz, formats.TiebreakTotalTime})
+
+	if s.TotalRounds != 3 {
+		t.Errorf("unexpected number of rounds, expected %v, got %v", 3, s.TotalRounds)
+	}
+
+	for !s.IsFinished() {
+		m := s.GetNextMatches()
+		if len(m) != 4 {
+			t.Fatalf("unexpected number of matches in round %v, expected %v, got %v", s.CurrentRound, 4, len(m))
+		}
+
+		wins := make(map[string]int)
+		for _, standing := range s.GetStandings() {
+			wins[standing.Player] = standing.Wins
+		}
+
+		for _, match := range m {
+			if wins[match.Player1] != wins[match.Player2] {
+				t.Errorf("round %v paired %v (%v wins) with %v (%v wins)", s.CurrentRound, match.Player1, wins[match.Player1], match.Player2, wins[match.Player2])
+			}
+
+			// The first player listed always wins.
+			if err := s.HandleGameResult(t.Context(), match.ID, []string{match.Player1, match.Player2}, []uint64{120000, 135000}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, match := range s.Matches {
+		pairing := match.Player1 + " " + match.Player2
+		if match.Player2 < match.Player1 {
+			pairing = match.Player2 + " " + match.Player1
+		}
+
+		if seen[pairing] {
+			t.Errorf("rematch scheduled: %v", pairing)
+		}
+		seen[pairing] = true
+	}
+
+	standings := s.GetStandings()
+	if standings[0].Wins != 3 || standings[0].Player != s.GetWinner() {
+		t.Errorf("unexpected winner %v with standings %v", s.GetWinner(), standings)
+	}
+
+	fmt.Println(s.GetBracketVisualization())
+}
+
+func TestSwissByes(t *testing.T) {
+	s := formats.NewSoloSwissState(t.Context(), "id", []string{"senez", "kha0x", "i77_", "tauktes", "yaweee"}, 0, []string{formats.TiebreakBuchholz})
+
+	for !s.IsFinished() {
+		for _, match := range s.GetNextMatches() {
+			if err := s.HandleGameResult(t.Context(), match.ID, []string{match.Player1, match.Player2}, []uint64{120000, 135000}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	if len(s.Byes) != s.TotalRounds {
+		t.Fatalf("unexpected number of byes, expected %v, got %v", s.TotalRounds, len(s.Byes))
+	}
+
+	seen := make(map[string]bool)
+	for _, bye := range s.Byes {
+		if seen[bye.Player] {
+			t.Errorf("player %v got more than one bye", bye.Player)
+		}
+		seen[bye.Player] = true
+	}
+}
+
+func TestSwissPairsLateRounds(t *testing.T) {
+	var players []string
+	for i := range 32 {
+		players = append(players, fmt.Sprintf("player%02d", i))
+	}
+
+	// With a round per opponent the last rounds run out of rematch-free
+	// pairings, which has to fall back to rematches instead of searching.
+	s := formats.NewSoloSwissState(t.Context(), "id", players, 31, []string{formats.TiebreakBuchholz})
+
+	for !s.IsFinished() {
+		m := s.GetNextMatches()
+		if len(m) != 16 {
+			t.Fatalf("unexpected number of matches in round %v, expected %v, got %v", s.CurrentRound, 16, len(m))
+		}
+
+		for _, match := range m {
+			// The second player listed always wins.
+			if err := s.HandleGameResult(t.Context(), match.ID, []string{match.Player2, match.Player1}, []uint64{120000, 135000}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	if len(s.Matches) != 31*16 {
+		t.Errorf("unexpected number of matches, expected %v, got %v", 31*16, len(s.Matches))
+	}
+}
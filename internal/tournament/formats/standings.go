@@ -0,0 +1,224 @@
+package formats
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+)
+
+type Standing struct {
+	Rank      int    `json:"rank"`
+	Player    string `json:"player"`
+	Wins      int    `json:"wins"`
+	Losses    int    `json:"losses"`
+	Played    int    `json:"played"`
+	TotalTime uint64 `json:"total_time"`
+	BestTime  uint64 `json:"best_time"`
+	Buchholz  int    `json:"buchholz,omitempty"`
+}
+
+type Placement struct {
+	Position int    `json:"position"`
+	Player   string `json:"player"`
+}
+
+// placementsByElimination ranks players of elimination formats by how long
+// they lasted. Players knocked out in the same round share a placement, so
+// both semifinal losers of a single elimination bracket finish 3rd.
+func placementsByElimination(winner string, matches []Match) []Placement {
+	eliminatedIn := make(map[string]int)
+	for _, match := range matches {
+		if !match.Finished {
+			continue
+		}
+
+		for _, player := range match.Participants() {
+			if player != match.Winner {
+				eliminatedIn[player] = match.Round
+			}
+		}
+	}
+	delete(eliminatedIn, winner)
+
+	players := slices.Collect(maps.Keys(eliminatedIn))
+	slices.SortFunc(players, func(a, b string) int {
+		return cmp.Or(eliminatedIn[b]-eliminatedIn[a], strings.Compare(a, b))
+	})
+
+	placements := []Placement{{Position: 1, Player: winner}}
+	for i, player := range players {
+		position := i + 2
+		if i > 0 && eliminatedIn[player] == eliminatedIn[players[i-1]] {
+			position = placements[len(placements)-1].Position
+		}
+		placements = append(placements, Placement{Position: position, Player: player})
+	}
+	return placements
+}
+
+func standingsPlacements(standings []Standing) []Placement {
+	placements := make([]Placement, len(standings))
+	for i, standing := range standings {
+		placements[i] = Placement{Position: standing.Rank, Player: standing.Player}
+	}
+	return placements
+}
+
+// Scoreboard tracks the per player results of formats that rank players by
+// wins instead of eliminating them.
+type Scoreboard struct {
+	Wins      map[string]int
+	Losses    map[string]int
+	Played    map[string]int
+	TotalTime map[string]uint64
+	BestTime  map[string]uint64
+}
+
+func newScoreboard() Scoreboard {
+	return Scoreboard{
+		Wins:      make(map[string]int),
+		Losses:    make(map[string]int),
+		Played:    make(map[string]int),
+		TotalTime: make(map[string]uint64),
+		BestTime:  make(map[string]uint64),
+	}
+}
+
+// recordTime adds the time of a single game. Times count per game, so every
+// game of a best-of-N series adds to the total.
+func (sb *Scoreboard) recordTime(player string, time uint64) {
+	sb.TotalTime[player] += time
+	if best, ok := sb.BestTime[player]; !ok || time < best {
+		sb.BestTime[player] = time
+	}
+}
+
+// recordMatch counts a finished match as a win or a loss.
+func (sb *Scoreboard) recordMatch(player string, won bool) {
+	sb.Played[player]++
+	if won {
+		sb.Wins[player]++
+	} else {
+		sb.Losses[player]++
+	}
+}
+
+// rank orders players by wins, breaking ties with the tiebreakers in order and
+// falling back to the order players were given in. Time based tiebreakers are
+// handled here, formatKey supplies the sort key for format specific ones
+// within a group of tied players and returns nil for unknown tiebreakers.
+//
+// Walkovers and byes count as wins without a time, so total time compares
+// the average time over the games of matches each player actually played
+// rather than the raw totals, which would favour players who raced less.
+func (sb *Scoreboard) rank(players []string, matches []Match, tiebreakers []string, formatKey func(tiebreaker string, group []string) func(string) int64) []string {
+	ranked := slices.Clone(players)
+	slices.SortStableFunc(ranked, func(a, b string) int {
+		return sb.Wins[b] - sb.Wins[a]
+	})
+
+	games := gamesPlayed(matches)
+
+	ordered := []string{}
+	for _, group := range groupBy(ranked, func(player string) int64 { return int64(sb.Wins[player]) }) {
+		ordered = append(ordered, sb.breakTies(group, games, tiebreakers, formatKey)...)
+	}
+	return ordered
+}
+
+func (sb *Scoreboard) breakTies(players []string, games map[string]int, tiebreakers []string, formatKey func(tiebreaker string, group []string) func(string) int64) []string {
+	if len(players) < 2 || len(tiebreakers) == 0 {
+		return players
+	}
+
+	var key func(player string) int64
+	switch tiebreakers[0] {
+	case TiebreakTotalTime:
+		// Averaged in microseconds, so totals over the same number of games
+		// still compare exactly.
+		key = func(player string) int64 {
+			if games[player] == 0 {
+				return math.MaxInt64
+			}
+			return int64(sb.TotalTime[player] * 1000 / uint64(games[player]))
+		}
+	case TiebreakBestTime:
+		key = func(player string) int64 {
+			if best, ok := sb.BestTime[player]; ok {
+				return int64(best)
+			}
+			return math.MaxInt64
+		}
+	default:
+		key = formatKey(tiebreakers[0], players)
+	}
+
+	if key == nil {
+		return sb.breakTies(players, games, tiebreakers[1:], formatKey)
+	}
+
+	sorted := slices.Clone(players)
+	slices.SortStableFunc(sorted, func(a, b string) int {
+		return cmp.Compare(key(a), key(b))
+	})
+
+	result := []string{}
+	for _, group := range groupBy(sorted, key) {
+		result = append(result, sb.breakTies(group, games, tiebreakers[1:], formatKey)...)
+	}
+	return result
+}
+
+func (sb *Scoreboard) standings(ordered []string) []Standing {
+	standings := make([]Standing, len(ordered))
+	for i, player := range ordered {
+		standings[i] = Standing{
+			Rank:      i + 1,
+			Player:    player,
+			Wins:      sb.Wins[player],
+			Losses:    sb.Losses[player],
+			Played:    sb.Played[player],
+			TotalTime: sb.TotalTime[player],
+			BestTime:  sb.BestTime[player],
+		}
+	}
+	return standings
+}
+
+// gamesPlayed counts the games each player has played. Walkovers add none.
+func gamesPlayed(matches []Match) map[string]int {
+	games := make(map[string]int)
+	for _, match := range matches {
+		for _, player := range match.Participants() {
+			games[player] += len(match.Games)
+		}
+	}
+	return games
+}
+
+// headToHeadWins counts the wins each player has against the other players in
+// the group.
+func headToHeadWins(matches []Match, players []string) map[string]int {
+	wins := make(map[string]int)
+	for _, match := range matches {
+		if match.Finished && slices.Contains(players, match.Player1) && slices.Contains(players, match.Player2) {
+			wins[match.Winner]++
+		}
+	}
+	return wins
+}
+
+// groupBy splits an already sorted slice into runs of elements sharing the
+// same key.
+func groupBy[T any](items []T, key func(T) int64) [][]T {
+	var groups [][]T
+	for i, item := range items {
+		if i == 0 || key(item) != key(items[i-1]) {
+			groups = append(groups, []T{})
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], item)
+	}
+	return groups
+}
@@ -0,0 +1,124 @@
+package tournament
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// StatusUpcoming is accepted by ListTournaments as another name for
+// StatusScheduled.
+const StatusUpcoming = "upcoming"
+
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+// ErrTournamentNotFound is returned for a tournament ID that doesn't exist.
+var ErrTournamentNotFound = errors.New("tournament not found")
+
+// TournamentFilter narrows down ListTournaments. Zero fields don't filter.
+type TournamentFilter struct {
+	Status string
+	Format string
+	// From and To bound the tournament date, both inclusive.
+	From *time.Time
+	To   *time.Time
+	// Ascending lists the earliest tournaments first instead of the latest.
+	Ascending bool
+	Limit     int
+	Offset    int
+}
+
+// TournamentSummary is a tournament as it appears in a listing.
+type TournamentSummary struct {
+	ID          string
+	Name        string
+	Date        uint64
+	Format      string
+	Status      string
+	Players     int
+	Winner      string
+	CompletedAt *time.Time
+}
+
+// TournamentPage is one page of a tournament listing, with the total
+// number of tournaments matching the filter across all pages.
+type TournamentPage struct {
+	Tournaments []TournamentSummary
+	Total       int
+	Limit       int
+	Offset      int
+}
+
+// TournamentDetails is everything known about a single tournament.
+type TournamentDetails struct {
+	Tournament
+	Active bool
+	// Players counts the participants like TournamentSummary does, leaving
+	// out the ones who withdrew or were disqualified.
+	Players int
+}
+
+// ListTournaments returns one page of the tournaments matching filter. The
+// limit defaults to DefaultListLimit and is capped at MaxListLimit.
+func (tm *TournamentManager) ListTournaments(ctx context.Context, filter TournamentFilter) (*TournamentPage, error) {
+	if filter.Status == StatusUpcoming {
+		filter.Status = StatusScheduled
+	}
+
+	switch filter.Status {
+	case "", StatusScheduled, StatusInProgress, StatusCompleted, StatusStopped:
+	default:
+		return nil, fmt.Errorf("unknown tournament status: %s", filter.Status)
+	}
+
+	if filter.Format != "" {
+		if _, exists := AvailableFormats[filter.Format]; !exists {
+			return nil, fmt.Errorf("unsupported format: %s", filter.Format)
+		}
+	}
+
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return nil, fmt.Errorf("date range ends before it starts")
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultListLimit
+	}
+	filter.Limit = min(filter.Limit, MaxListLimit)
+	filter.Offset = max(filter.Offset, 0)
+
+	tournaments, total, err := tm.tournaments.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TournamentPage{Tournaments: tournaments, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
+
+// GetTournament returns a tournament with its players, whether or not it is
+// active.
+func (tm *TournamentManager) GetTournament(ctx context.Context, tournamentID string) (*TournamentDetails, error) {
+	tournament, err := tm.tournaments.Get(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := tm.players.List(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get players for tournament: %w", err)
+	}
+	tournament.Participants = players
+
+	count := 0
+	for _, player := range players {
+		if player.Status != PlayerWithdrawn && player.Status != PlayerDisqualified {
+			count++
+		}
+	}
+
+	return &TournamentDetails{Tournament: *tournament, Active: tm.IsActive(tournamentID), Players: count}, nil
+}
@@ -0,0 +1,87 @@
+package tournament_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"tournament-manager/internal/tournament"
+	"tournament-manager/internal/tournament/formats"
+)
+
+func TestListTournaments(t *testing.T) {
+	tm, _, played := newTournament(t)
+	for range 3 {
+		play(t, tm, played)
+	}
+
+	day := uint64(time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC).Unix())
+	upcoming, _ := tm.CreateTournament(t.Context(), "upcoming", day, "solo_round_robin", formats.Options{}, nil)
+	later, _ := tm.CreateTournament(t.Context(), "later", day+86400, "solo_single_elim", formats.Options{}, nil)
+
+	page, err := tm.ListTournaments(t.Context(), tournament.TournamentFilter{})
+	if err != nil {
+		t.Fatalf("failed to list tournaments: %v", err)
+	}
+
+	if page.Total != 3 || len(page.Tournaments) != 3 || page.Tournaments[0].ID != later {
+		t.Errorf("unexpected listing, expected 3 tournaments latest first, got %v", page.Tournaments)
+	}
+
+	if page.Limit != tournament.DefaultListLimit {
+		t.Errorf("unexpected limit, expected %v, got %v", tournament.DefaultListLimit, page.Limit)
+	}
+
+	page, _ = tm.ListTournaments(t.Context(), tournament.TournamentFilter{Status: tournament.StatusCompleted})
+	if len(page.Tournaments) != 1 {
+		t.Fatalf("unexpected number of completed tournaments, expected 1, got %d", len(page.Tournaments))
+	}
+
+	if summary := page.Tournaments[0]; summary.ID != played || summary.Winner != "senez" || summary.Players != 4 || summary.CompletedAt == nil {
+		t.Errorf("unexpected completed tournament, got %+v", summary)
+	}
+
+	page, _ = tm.ListTournaments(t.Context(), tournament.TournamentFilter{Status: tournament.StatusUpcoming, Format: "solo_round_robin"})
+	if len(page.Tournaments) != 1 || page.Tournaments[0].ID != upcoming {
+		t.Errorf("unexpected upcoming round robin tournaments, expected %v, got %v", upcoming, page.Tournaments)
+	}
+
+	from := time.Unix(int64(day), 0)
+	page, _ = tm.ListTournaments(t.Context(), tournament.TournamentFilter{From: &from, Ascending: true, Limit: 1, Offset: 1})
+	if page.Total != 2 || len(page.Tournaments) != 1 || page.Tournaments[0].ID != later {
+		t.Errorf("unexpected second page, expected %v of 2, got %v of %d", later, page.Tournaments, page.Total)
+	}
+
+	if _, err := tm.ListTournaments(t.Context(), tournament.TournamentFilter{Status: "paused"}); err == nil {
+		t.Errorf("expected an unknown status to be rejected")
+	}
+}
+
+func TestGetTournament(t *testing.T) {
+	tm, _, id := newTournament(t)
+
+	details, err := tm.GetTournament(t.Context(), id)
+	if err != nil {
+		t.Fatalf("failed to get tournament: %v", err)
+	}
+
+	if !details.Active || details.Status != tournament.StatusInProgress || len(details.Participants) != 4 {
+		t.Errorf("unexpected details, got %+v", details)
+	}
+
+	if details.Players != 4 {
+		t.Errorf("unexpected player count, expected %v, got %v", 4, details.Players)
+	}
+
+	if err := tm.Forfeit(t.Context(), id, details.Participants[0].IGN, tournament.WithdrawAction, "left"); err != nil {
+		t.Fatalf("failed to withdraw player: %v", err)
+	}
+
+	details, _ = tm.GetTournament(t.Context(), id)
+	if details.Players != 3 || len(details.Participants) != 4 {
+		t.Errorf("unexpected player count after a withdrawal, expected %v of %v, got %v of %v", 3, 4, details.Players, len(details.Participants))
+	}
+
+	if _, err := tm.GetTournament(t.Context(), "missing"); !errors.Is(err, tournament.ErrTournamentNotFound) {
+		t.Errorf("unexpected error, expected %v, got %v", tournament.ErrTournamentNotFound, err)
+	}
+}
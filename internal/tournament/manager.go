@@ -1,148 +1,288 @@
 package tournament
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"slices"
 	"sync"
-	"tournament-manager/internal/database"
+	"time"
 	"tournament-manager/internal/tournament/formats"
 	"tournament-manager/internal/util"
 )
 
 type TournamentManager struct {
-	activeTournaments map[string]*formats.SoloSingleElimState
-	mu                sync.RWMutex
+	activeTournaments map[string]formats.Format
+	events            *EventBus
+	tournaments       TournamentRepository
+	players           PlayerRepository
+	results           ResultRepository
+	deadlines         DeadlineRepository
+	// failedStarts holds the tournaments the scheduler failed to start.
+	failedStarts map[string]bool
+	mu           sync.RWMutex
 }
 
+// Manager is the TournamentManager the HTTP handlers use. main sets it up
+// once the database is connected.
 var Manager *TournamentManager
 
-func init() {
-	Manager = &TournamentManager{
-		activeTournaments: make(map[string]*formats.SoloSingleElimState),
+func NewTournamentManager(repos Repositories) *TournamentManager {
+	return &TournamentManager{
+		activeTournaments: make(map[string]formats.Format),
+		failedStarts:      make(map[string]bool),
+		events:            NewEventBus(),
+		tournaments:       repos.Tournaments,
+		players:           repos.Players,
+		results:           repos.Results,
+		deadlines:         repos.Deadlines,
 	}
 }
 
-func (tm *TournamentManager) StartTournament(tournamentID string) error {
+// StartTournament builds the bracket of a tournament and starts it. For a
+// tournament with check-in, players who didn't check in are left out of the
+// bracket and returned.
+func (tm *TournamentManager) StartTournament(ctx context.Context, tournamentID string) ([]string, error) {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 
 	if _, exists := tm.activeTournaments[tournamentID]; exists {
-		return fmt.Errorf("tournament %s is already active", tournamentID)
+		return nil, fmt.Errorf("tournament %s is already active", tournamentID)
 	}
 
-	tournament, err := tm.getTournamentFromDB(tournamentID)
+	tournament, err := tm.tournaments.Get(ctx, tournamentID)
 	if err != nil {
-		return fmt.Errorf("failed to get tournament from database: %w", err)
+		return nil, fmt.Errorf("failed to get tournament from database: %w", err)
 	}
 
-	players, err := tm.getPlayersForTournament(tournamentID)
+	players, err := tm.getPlayersForTournament(ctx, tournamentID)
 	if err != nil {
-		return fmt.Errorf("failed to get players for tournament: %w", err)
+		return nil, fmt.Errorf("failed to get players for tournament: %w", err)
+	}
+
+	var dropped []string
+	if tournament.CheckIn != nil {
+		if players, dropped, err = tm.checkedInPlayers(ctx, tournamentID, players); err != nil {
+			return nil, err
+		}
 	}
 
 	if len(players) < 2 {
-		return fmt.Errorf("tournament needs at least 2 players, got %d", len(players))
+		return nil, fmt.Errorf("tournament needs at least 2 players, got %d", len(players))
 	}
 
-	switch tournament.Format {
-	case "solo_single_elim":
-		state := formats.NewSoloSingleElimState(tournamentID, players)
-		if state == nil {
-			return fmt.Errorf("failed to create tournament state")
-		}
-		tm.activeTournaments[tournamentID] = state
-		slog.Info("Tournament started", "tournament_id", tournamentID, "format", tournament.Format, "players", len(players))
-	default:
-		return fmt.Errorf("unsupported tournament format: %s", tournament.Format)
+	state, err := formats.New(ctx, tournament.Format, tournamentID, players, tournament.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tournament state: %w", err)
 	}
 
-	return nil
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tournament state: %w", err)
+	}
+
+	if err := tm.tournaments.Start(ctx, tournamentID, players, data); err != nil {
+		return nil, err
+	}
+
+	tm.activeTournaments[tournamentID] = state
+	tm.syncDeadlines(ctx, tournamentID, state, time.Now())
+	slog.InfoContext(ctx, "Tournament started", "tournament_id", tournamentID, "format", tournament.Format, "players", len(players), "dropped", dropped)
+
+	tm.publish(tournamentID, EventTournamentStarted, map[string]interface{}{
+		"format":  tournament.Format,
+		"players": players,
+		"dropped": dropped,
+	})
+	tm.publishMatchChanges(tournamentID, nil, state.GetNextMatches())
+
+	return dropped, nil
+}
+
+// ErrConflictingResult is returned for a resubmission of a game whose
+// results differ from the ones submitted before.
+var ErrConflictingResult = errors.New("game result conflicts with an earlier submission")
+
+func (tm *TournamentManager) HandleGameResult(ctx context.Context, tournamentID, gameID string, players []string, timesStr []string) error {
+	_, err := tm.SubmitGameResult(ctx, tournamentID, "", gameID, players, timesStr)
+	return err
 }
 
-func (tm *TournamentManager) HandleGameResult(tournamentID, gameID string, players []string, timesStr []string) error {
+// SubmitGameResult handles a game result unless it repeats an earlier
+// submission, in which case it reports the result as replayed and changes
+// nothing. A submission is recognised by its idempotency key or, without a
+// key, by having the same game ID and results as the latest submission for
+// that game ID. A repeat with different results fails with
+// ErrConflictingResult; without a key that is only once the game can no
+// longer be played, since a bare match ID in a series stands for whichever
+// game is next.
+func (tm *TournamentManager) SubmitGameResult(ctx context.Context, tournamentID, key, gameID string, players []string, timesStr []string) (bool, error) {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 
-	state, exists := tm.activeTournaments[tournamentID]
-	if !exists {
-		return fmt.Errorf("tournament %s is not active", tournamentID)
+	if len(players) == 0 || len(players) != len(timesStr) {
+		return false, fmt.Errorf("expected a time for each of the %d players, got %d", len(players), len(timesStr))
 	}
 
 	times := make([]uint64, len(timesStr))
 	for i, timeStr := range timesStr {
 		time, err := util.ParseTime(timeStr)
 		if err != nil {
-			return fmt.Errorf("failed to parse time for player %s: %w", players[i], err)
+			return false, fmt.Errorf("failed to parse time for player %s: %w", players[i], err)
 		}
 		times[i] = time
 	}
 
-	type result struct {
-		ign  string
-		time uint64
-		pos  int
-	}
-	results := make([]result, len(players))
-	for i, player := range players {
-		results[i] = result{ign: player, time: times[i]}
+	submission := Submission{GameID: gameID, Key: key, Players: players, Times: times}
+
+	earlier, err := tm.results.FindSubmission(ctx, tournamentID, key, gameID)
+	if err != nil {
+		return false, err
 	}
 
-	slices.SortFunc(results, func(a, b result) int {
-		if a.time < b.time {
-			return -1
+	if earlier != nil {
+		if sameResults(*earlier, submission) {
+			slog.InfoContext(ctx, "Replaying earlier game result", "tournament_id", tournamentID, "game_id", gameID, "key", key)
+			return true, nil
 		}
-		if a.time > b.time {
-			return 1
+
+		if key != "" {
+			return false, ErrConflictingResult
 		}
-		return 0
-	})
+	}
 
-	for i, res := range results {
-		results[i].pos = i + 1
-		sql := `
-			INSERT INTO GameResult (game_id, tournament_id, player_id, position, time)
-			VALUES ($1, $2, $3, $4, $5)
-		`
+	err = tm.recordGameResult(ctx, tournamentID, submission)
+	if err != nil && earlier != nil && !errors.Is(err, errRecordFailed) {
+		return false, ErrConflictingResult
+	}
 
-		if i > 0 && res.time == results[i-1].time {
-			results[i].pos = results[i-1].pos
+	return false, err
+}
+
+// sameResults reports whether two submissions are for the same game and
+// give every player the same time, in whatever order they were listed.
+func sameResults(a, b Submission) bool {
+	if a.GameID != b.GameID || len(a.Players) != len(b.Players) {
+		return false
+	}
+
+	times := make(map[string]uint64, len(a.Players))
+	for i, player := range a.Players {
+		times[player] = a.Times[i]
+	}
+
+	for i, player := range b.Players {
+		if time, ok := times[player]; !ok || time != b.Times[i] {
+			return false
 		}
+	}
 
-		playerID, err := getPlayerUUIDByIGN(tournamentID, res.ign)
-		if err != nil {
-			err = fmt.Errorf("failed to get player UUID for IGN %s: %w", res.ign, err)
-			slog.Error(err.Error())
-			return err
+	return true
+}
+
+// rankResults returns the results of a submitted game, positioned by time
+// with tied times sharing a position. Forfeits have no results.
+func rankResults(submission Submission) []formats.GameResult {
+	if submission.Kind != "" {
+		return nil
+	}
+
+	results := make([]formats.GameResult, len(submission.Players))
+	for i, player := range submission.Players {
+		results[i] = formats.GameResult{GameID: submission.GameID, Player: player, Time: submission.Times[i]}
+	}
+
+	slices.SortStableFunc(results, func(a, b formats.GameResult) int {
+		return cmp.Compare(a.Time, b.Time)
+	})
+
+	for i := range results {
+		results[i].Position = i + 1
+		if i > 0 && results[i].Time == results[i-1].Time {
+			results[i].Position = results[i-1].Position
 		}
+	}
 
-		if _, err := database.DB.Exec(context.TODO(), sql, gameID, tournamentID, playerID, results[i].pos, res.time); err != nil {
-			err = fmt.Errorf("failed to save game result for game %v: %v", res, err)
-			slog.Error(err.Error())
-			return err
+	return results
+}
+
+// errRecordFailed marks a submission the bracket accepted but that could not
+// be stored.
+var errRecordFailed = errors.New("failed to record game result")
+
+func (tm *TournamentManager) recordGameResult(ctx context.Context, tournamentID string, submission Submission) error {
+	return tm.recordSubmission(ctx, tournamentID, submission, func(state formats.Format) error {
+		if err := state.HandleGameResult(ctx, submission.GameID, submission.Players, submission.Times); err != nil {
+			return fmt.Errorf("failed to handle game result: %w", err)
 		}
+		return nil
+	}, EventResultSubmitted, map[string]interface{}{
+		"game_id": submission.GameID,
+		"players": submission.Players,
+		"times":   submission.Times,
+	})
+}
+
+// recordSubmission applies a submission to an active tournament with apply
+// and publishes event with data once it is stored.
+func (tm *TournamentManager) recordSubmission(ctx context.Context, tournamentID string, submission Submission, apply func(formats.Format) error, event EventType, data map[string]interface{}) error {
+	state, exists := tm.activeTournaments[tournamentID]
+	if !exists {
+		return fmt.Errorf("tournament %s is not active", tournamentID)
 	}
 
-	err := state.HandleGameResult(gameID, players, times)
+	// The submission is applied to a copy of the state, which only replaces
+	// the live one once the submission and the new state are stored, so a
+	// rejected or failed submission leaves both the database and the
+	// bracket untouched.
+	next, err := formats.Clone(state)
 	if err != nil {
-		return fmt.Errorf("failed to handle game result: %w", err)
+		return fmt.Errorf("failed to copy tournament state: %w", err)
 	}
 
-	if state.IsComplete {
-		slog.Info("Tournament completed", "tournament_id", tournamentID, "winner", state.Winner)
+	if err := apply(next); err != nil {
+		return err
+	}
 
-		if err := tm.saveTournamentResults(tournamentID, state); err != nil {
-			slog.Warn("Failed to save tournament results", "tournament_id", tournamentID, "error", err)
-		}
+	encoded, err := json.Marshal(next)
+	if err != nil {
+		return fmt.Errorf("failed to encode tournament state: %w", err)
+	}
+
+	var completed *TournamentResults
+	if next.IsFinished() {
+		completed = finalResults(tournamentID, next)
+	}
+
+	if err := tm.results.RecordGame(ctx, tournamentID, submission, rankResults(submission), encoded, completed); err != nil {
+		slog.ErrorContext(ctx, "Failed to record submission", "tournament_id", tournamentID, "game_id", submission.GameID, "kind", submission.Kind, "error", err)
+		return fmt.Errorf("%w: %w", errRecordFailed, err)
+	}
+
+	tm.activeTournaments[tournamentID] = next
+	tm.syncDeadlines(ctx, tournamentID, next, time.Now())
+
+	tm.publish(tournamentID, event, data)
+	tm.publishMatchChanges(tournamentID, state.GetNextMatches(), next.GetNextMatches())
+
+	if completed != nil {
+		slog.InfoContext(ctx, "Tournament completed", "tournament_id", tournamentID, "winner", completed.Winner)
 
 		delete(tm.activeTournaments, tournamentID)
+
+		tm.publish(tournamentID, EventTournamentCompleted, map[string]interface{}{
+			"winner":     completed.Winner,
+			"placements": completed.Placements,
+		})
 	}
 
 	return nil
 }
 
-func (tm *TournamentManager) GetTournamentState(tournamentID string) (*formats.SoloSingleElimState, error) {
+func (tm *TournamentManager) GetTournamentState(tournamentID string) (formats.Format, error) {
 	tm.mu.RLock()
 	defer tm.mu.RUnlock()
 
@@ -181,6 +321,53 @@ func (tm *TournamentManager) GetBracket(tournamentID string) (string, error) {
 	return state.GetBracketVisualization(), nil
 }
 
+// GetBracketData returns the structured bracket of an active or completed
+// tournament with the times of every game filled in from the recorded game
+// results.
+func (tm *TournamentManager) GetBracketData(ctx context.Context, tournamentID string) (formats.Bracket, error) {
+	state, err := tm.GetTournamentState(tournamentID)
+	if err != nil {
+		if state, err = tm.completedState(ctx, tournamentID); err != nil {
+			return formats.Bracket{}, err
+		}
+	}
+
+	bracket := state.GetBracket()
+	if err := tm.attachTimes(ctx, tournamentID, &bracket); err != nil {
+		return formats.Bracket{}, err
+	}
+
+	return bracket, nil
+}
+
+// completedState rebuilds the final state of a completed tournament, which
+// is no longer kept once it completes, by replaying its submissions.
+func (tm *TournamentManager) completedState(ctx context.Context, tournamentID string) (formats.Format, error) {
+	tournament, err := tm.tournaments.Get(ctx, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tournament from database: %w", err)
+	}
+
+	if tournament.Status != StatusCompleted {
+		return nil, fmt.Errorf("tournament %s is neither active nor completed", tournamentID)
+	}
+
+	submissions, err := tm.results.Submissions(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	seeding := tournament.Seeding
+	if len(seeding) == 0 {
+		if seeding, err = tm.getPlayersForTournament(ctx, tournamentID); err != nil {
+			return nil, fmt.Errorf("failed to get players for tournament: %w", err)
+		}
+	}
+
+	state, _, _, err := replay(ctx, tournament.Format, tournamentID, seeding, tournament.Options, submissions)
+	return state, err
+}
+
 func (tm *TournamentManager) IsActive(tournamentID string) bool {
 	tm.mu.RLock()
 	defer tm.mu.RUnlock()
@@ -189,7 +376,7 @@ func (tm *TournamentManager) IsActive(tournamentID string) bool {
 	return exists
 }
 
-func (tm *TournamentManager) StopTournament(tournamentID string) error {
+func (tm *TournamentManager) StopTournament(ctx context.Context, tournamentID string) error {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 
@@ -197,8 +384,18 @@ func (tm *TournamentManager) StopTournament(tournamentID string) error {
 		return fmt.Errorf("tournament %s is not active", tournamentID)
 	}
 
+	if err := tm.tournaments.DeleteState(ctx, tournamentID); err != nil {
+		return err
+	}
+
+	if err := tm.tournaments.SetStatus(ctx, tournamentID, StatusStopped); err != nil {
+		slog.WarnContext(ctx, "Failed to update tournament status", "tournament_id", tournamentID, "error", err)
+	}
+
 	delete(tm.activeTournaments, tournamentID)
-	slog.Info("Tournament stopped", "tournament_id", tournamentID)
+	slog.InfoContext(ctx, "Tournament stopped", "tournament_id", tournamentID)
+
+	tm.publish(tournamentID, EventTournamentStopped, nil)
 	return nil
 }
 
@@ -213,66 +410,43 @@ func (tm *TournamentManager) ListActiveTournaments() []string {
 	return tournaments
 }
 
-func (tm *TournamentManager) getTournamentFromDB(tournamentID string) (*Tournament, error) {
-	query := "SELECT id, name, date, format FROM Tournament WHERE id = $1"
-	row := database.DB.QueryRow(context.Background(), query, tournamentID)
+func (tm *TournamentManager) getPlayersForTournament(ctx context.Context, tournamentID string) ([]string, error) {
+	slog.DebugContext(ctx, "Getting players for tournament", "tournament_id", tournamentID)
 
-	var tournament Tournament
-	err := row.Scan(&tournament.ID, &tournament.Name, &tournament.Date, &tournament.Format)
+	// Manual seeds come first, everyone else is seeded by personal best.
+	players, err := tm.players.SeedOrder(ctx, tournamentID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to scan tournament: %w", err)
+		return nil, err
 	}
 
-	return &tournament, nil
+	slog.DebugContext(ctx, "Retrieved players from database", "tournament_id", tournamentID, "count", len(players), "players", players)
+	return players, nil
 }
 
-func (tm *TournamentManager) getPlayersForTournament(tournamentID string) ([]string, error) {
-	slog.Debug("Getting players for tournament", "tournament_id", tournamentID)
-
-	query := "SELECT ign FROM Player WHERE tournament_id = $1"
-	rows, err := database.DB.Query(context.Background(), query, tournamentID)
+// attachTimes fills in the times of every bracket slot from the recorded game
+// results, grouping the games of a series by the match they belong to.
+func (tm *TournamentManager) attachTimes(ctx context.Context, tournamentID string, bracket *formats.Bracket) error {
+	results, err := tm.results.GameResults(ctx, tournamentID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query players: %w", err)
+		return err
 	}
-	defer rows.Close()
 
-	var players []string
-	for rows.Next() {
-		var ign string
-		if err := rows.Scan(&ign); err != nil {
-			return nil, fmt.Errorf("failed to scan player: %w", err)
+	times := make(map[string]map[string][]uint64)
+	for _, result := range results {
+		matchID, _ := formats.SplitGameID(result.GameID)
+		if times[matchID] == nil {
+			times[matchID] = make(map[string][]uint64)
 		}
-		players = append(players, ign)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating players: %w", err)
+		times[matchID][result.Player] = append(times[matchID][result.Player], result.Time)
 	}
 
-	slog.Debug("Retrieved players from database", "tournament_id", tournamentID, "count", len(players), "players", players)
-	return players, nil
-}
-
-func getPlayerUUIDByIGN(tournamentID, ign string) (string, error) {
-	query := "SELECT id FROM Player WHERE tournament_id = $1 AND ign = $2"
-	row := database.DB.QueryRow(context.Background(), query, tournamentID, ign)
-
-	var playerID string
-	err := row.Scan(&playerID)
-	if err != nil {
-		return "", fmt.Errorf("failed to get player UUID for IGN %s: %w", ign, err)
+	for _, round := range bracket.Rounds {
+		for _, match := range round.Matches {
+			for i := range match.Slots {
+				match.Slots[i].Times = times[match.ID][match.Slots[i].Player]
+			}
+		}
 	}
 
-	return playerID, nil
-}
-
-func (tm *TournamentManager) saveTournamentResults(tournamentID string, state *formats.SoloSingleElimState) error {
-	slog.Debug("Saving tournament results", "tournament_id", tournamentID, "winner", state.Winner)
-
-	// TODO: Implement database operations to save:
-	// - Match results
-	// - Final winner
-	// - Tournament completion status
-
 	return nil
 }
@@ -0,0 +1,361 @@
+package tournament_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"tournament-manager/internal/logging"
+	"tournament-manager/internal/tournament"
+	"tournament-manager/internal/tournament/formats"
+)
+
+// newTournament creates a started single elimination tournament with four
+// players, seeded in the order they are listed.
+func newTournament(t *testing.T) (*tournament.TournamentManager, tournament.Repositories, string) {
+	t.Helper()
+
+	repos := tournament.NewMemoryRepositories()
+	tm := tournament.NewTournamentManager(repos)
+
+	id, err := tm.CreateTournament(t.Context(), "test", 0, "solo_single_elim", formats.Options{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tournament: %v", err)
+	}
+
+	for i, ign := range []string{"senez", "kha0x", "i77_", "tauktes"} {
+		if err := tm.Signup(t.Context(), ign, ign, uint64(120000+i*1000), id); err != nil {
+			t.Fatalf("failed to sign up %v: %v", ign, err)
+		}
+	}
+
+	if _, err := tm.StartTournament(t.Context(), id); err != nil {
+		t.Fatalf("failed to start tournament: %v", err)
+	}
+
+	return tm, repos, id
+}
+
+func TestManagerPlaysTournament(t *testing.T) {
+	tm, repos, id := newTournament(t)
+
+	events, unsubscribe := tm.Subscribe(id)
+	defer unsubscribe()
+
+	for tm.IsActive(id) {
+		m, err := tm.GetNextMatches(id)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := tm.HandleGameResult(t.Context(), id, m[0].ID, []string{m[0].Player1, m[0].Player2}, []string{"2:00.0", "2:15.0"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	results, err := tm.GetTournamentResults(t.Context(), id)
+	if err != nil {
+		t.Fatalf("failed to get results: %v", err)
+	}
+
+	if results.Winner != "senez" {
+		t.Errorf("unexpected winner, expected %v, got %v", "senez", results.Winner)
+	}
+
+	if len(results.Matches) != 3 || len(results.Placements) != 4 {
+		t.Errorf("unexpected results, got %+v", results)
+	}
+
+	saved, err := repos.Tournaments.Get(t.Context(), id)
+	if err != nil || saved.Status != tournament.StatusCompleted {
+		t.Errorf("unexpected status, expected %v, got %v", tournament.StatusCompleted, saved)
+	}
+
+	gameResults, _ := repos.Results.GameResults(t.Context(), id)
+	if len(gameResults) != 6 {
+		t.Errorf("unexpected number of game results, expected %v, got %v", 6, len(gameResults))
+	}
+
+	var last tournament.Event
+	for len(events) > 0 {
+		last = <-events
+	}
+
+	if last.Type != tournament.EventTournamentCompleted {
+		t.Errorf("unexpected last event, expected %v, got %v", tournament.EventTournamentCompleted, last.Type)
+	}
+}
+
+func TestManagerRestoresTournaments(t *testing.T) {
+	tm, repos, id := newTournament(t)
+
+	m, _ := tm.GetNextMatches(id)
+	if err := tm.HandleGameResult(t.Context(), id, m[0].ID, []string{m[0].Player1, m[0].Player2}, []string{"2:00.0", "2:15.0"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	restarted := tournament.NewTournamentManager(repos)
+	if err := restarted.LoadActiveTournaments(t.Context()); err != nil {
+		t.Fatalf("failed to load tournaments: %v", err)
+	}
+
+	next, err := restarted.GetNextMatches(id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(next) != 1 || next[0].ID != m[1].ID {
+		t.Errorf("unexpected next matches, expected %v, got %v", m[1].ID, next)
+	}
+}
+
+func TestManagerBracketOfCompletedTournament(t *testing.T) {
+	tm, repos, id := newTournament(t)
+
+	for tm.IsActive(id) {
+		play(t, tm, id)
+	}
+
+	restarted := tournament.NewTournamentManager(repos)
+	if err := restarted.LoadActiveTournaments(t.Context()); err != nil {
+		t.Fatalf("failed to load tournaments: %v", err)
+	}
+
+	bracket, err := restarted.GetBracketData(t.Context(), id)
+	if err != nil {
+		t.Fatalf("failed to get bracket: %v", err)
+	}
+
+	if !bracket.Complete || bracket.Winner != "senez" {
+		t.Errorf("unexpected bracket, expected %v to have won, got %+v", "senez", bracket)
+	}
+
+	final := bracket.Rounds[len(bracket.Rounds)-1].Matches[0]
+	if len(final.Slots[0].Times) != 1 || final.Slots[0].Times[0] != 120000 {
+		t.Errorf("unexpected final times, expected %v, got %v", []uint64{120000}, final.Slots[0].Times)
+	}
+}
+
+func TestManagerRejectsUnknownPlayers(t *testing.T) {
+	tm, _, id := newTournament(t)
+
+	m, _ := tm.GetNextMatches(id)
+	if err := tm.HandleGameResult(t.Context(), id, m[0].ID, []string{m[0].Player1, "yaweee"}, []string{"2:00.0", "2:15.0"}); err == nil {
+		t.Errorf("expected an error for a player not signed up")
+	}
+}
+
+// failingResults fails every game it is asked to record.
+type failingResults struct {
+	tournament.ResultRepository
+}
+
+func (failingResults) RecordGame(context.Context, string, tournament.Submission, []formats.GameResult, []byte, *tournament.TournamentResults) error {
+	return errors.New("connection reset")
+}
+
+func TestManagerKeepsStateWhenRecordingFails(t *testing.T) {
+	repos := tournament.NewMemoryRepositories()
+	tm := tournament.NewTournamentManager(repos)
+
+	id, _ := tm.CreateTournament(t.Context(), "test", 0, "solo_single_elim", formats.Options{}, nil)
+	for _, ign := range []string{"senez", "kha0x"} {
+		if err := tm.Signup(t.Context(), ign, ign, 120000, id); err != nil {
+			t.Fatalf("failed to sign up %v: %v", ign, err)
+		}
+	}
+	if _, err := tm.StartTournament(t.Context(), id); err != nil {
+		t.Fatalf("failed to start tournament: %v", err)
+	}
+
+	failing := tournament.NewTournamentManager(tournament.Repositories{
+		Tournaments: repos.Tournaments,
+		Players:     repos.Players,
+		Results:     failingResults{repos.Results},
+		Deadlines:   repos.Deadlines,
+	})
+	if err := failing.LoadActiveTournaments(t.Context()); err != nil {
+		t.Fatalf("failed to load tournaments: %v", err)
+	}
+
+	m, _ := failing.GetNextMatches(id)
+	if err := failing.HandleGameResult(t.Context(), id, m[0].ID, []string{m[0].Player1, m[0].Player2}, []string{"2:00.0", "2:15.0"}); err == nil {
+		t.Fatalf("expected an error when the result can't be recorded")
+	}
+
+	if !failing.IsActive(id) {
+		t.Fatalf("unexpected completed tournament after a failed result")
+	}
+
+	after, _ := failing.GetNextMatches(id)
+	if len(after) != 1 || after[0].ID != m[0].ID || after[0].Winner != "" {
+		t.Errorf("unexpected pending matches, expected %v, got %v", m, after)
+	}
+
+	saved, _ := repos.Results.GameResults(t.Context(), id)
+	if len(saved) != 0 {
+		t.Errorf("unexpected number of stored game results, expected 0, got %d", len(saved))
+	}
+}
+
+func TestManagerReplaysResubmittedResults(t *testing.T) {
+	tm, repos, id := newTournament(t)
+
+	m, _ := tm.GetNextMatches(id)
+	players := []string{m[0].Player1, m[0].Player2}
+
+	replayed, err := tm.SubmitGameResult(t.Context(), id, "retry-1", m[0].ID, players, []string{"2:00.0", "2:15.0"})
+	if err != nil || replayed {
+		t.Fatalf("unexpected first submission, expected a new result, got replayed %v, error %v", replayed, err)
+	}
+
+	replayed, err = tm.SubmitGameResult(t.Context(), id, "retry-1", m[0].ID, players, []string{"2:00.000", "2:15.000"})
+	if err != nil || !replayed {
+		t.Errorf("unexpected retry with the same key, expected a replay, got replayed %v, error %v", replayed, err)
+	}
+
+	replayed, err = tm.SubmitGameResult(t.Context(), id, "", m[0].ID, []string{players[1], players[0]}, []string{"2:15.0", "2:00.0"})
+	if err != nil || !replayed {
+		t.Errorf("unexpected retry without a key, expected a replay, got replayed %v, error %v", replayed, err)
+	}
+
+	saved, _ := repos.Results.GameResults(t.Context(), id)
+	if len(saved) != 2 {
+		t.Errorf("unexpected number of stored game results, expected 2, got %d", len(saved))
+	}
+}
+
+func TestManagerRejectsConflictingResubmissions(t *testing.T) {
+	tm, _, id := newTournament(t)
+
+	m, _ := tm.GetNextMatches(id)
+	players := []string{m[0].Player1, m[0].Player2}
+
+	if _, err := tm.SubmitGameResult(t.Context(), id, "retry-1", m[0].ID, players, []string{"2:00.0", "2:15.0"}); err != nil {
+		t.Fatalf("failed to submit result: %v", err)
+	}
+
+	if _, err := tm.SubmitGameResult(t.Context(), id, "retry-1", m[0].ID, players, []string{"2:30.0", "2:15.0"}); !errors.Is(err, tournament.ErrConflictingResult) {
+		t.Errorf("unexpected error for a reused key, expected %v, got %v", tournament.ErrConflictingResult, err)
+	}
+
+	if _, err := tm.SubmitGameResult(t.Context(), id, "", m[0].ID, players, []string{"2:30.0", "2:15.0"}); !errors.Is(err, tournament.ErrConflictingResult) {
+		t.Errorf("unexpected error for a conflicting result, expected %v, got %v", tournament.ErrConflictingResult, err)
+	}
+}
+
+func TestManagerReplaysKeylessSeriesResubmission(t *testing.T) {
+	repos := tournament.NewMemoryRepositories()
+	tm := tournament.NewTournamentManager(repos)
+
+	id, err := tm.CreateTournament(t.Context(), "test", 0, "solo_single_elim", formats.Options{BestOf: []int{3}}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tournament: %v", err)
+	}
+
+	for i, ign := range []string{"senez", "kha0x"} {
+		if err := tm.Signup(t.Context(), ign, ign, uint64(120000+i*1000), id); err != nil {
+			t.Fatalf("failed to sign up %v: %v", ign, err)
+		}
+	}
+
+	if _, err := tm.StartTournament(t.Context(), id); err != nil {
+		t.Fatalf("failed to start tournament: %v", err)
+	}
+
+	m, _ := tm.GetNextMatches(id)
+	players := []string{m[0].Player1, m[0].Player2}
+
+	for i := 0; i < 2; i++ {
+		if _, err := tm.SubmitGameResult(t.Context(), id, "", m[0].ID, players, []string{"2:00.0", "2:15.0"}); err != nil {
+			t.Fatalf("failed to submit result: %v", err)
+		}
+	}
+
+	if !tm.IsActive(id) {
+		t.Fatalf("expected the series to still be pending after a retried game")
+	}
+
+	m, _ = tm.GetNextMatches(id)
+	if len(m) != 1 || m[0].Wins[players[0]] != 1 {
+		t.Errorf("unexpected series after a retried game, expected 1 win for %v, got %v", players[0], m)
+	}
+
+	saved, _ := repos.Results.GameResults(t.Context(), id)
+	if len(saved) != 2 {
+		t.Errorf("unexpected number of stored game results, expected 2, got %d", len(saved))
+	}
+}
+
+func TestManagerReusesVoidedKey(t *testing.T) {
+	tm, repos, id := newTournament(t)
+
+	m, _ := tm.GetNextMatches(id)
+	players := []string{m[0].Player1, m[0].Player2}
+
+	if _, err := tm.SubmitGameResult(t.Context(), id, "retry-1", m[0].ID, players, []string{"2:00.0", "2:15.0"}); err != nil {
+		t.Fatalf("failed to submit result: %v", err)
+	}
+
+	if _, err := tm.VoidGameResult(t.Context(), id, m[0].ID, "wrong lobby", false); err != nil {
+		t.Fatalf("failed to void result: %v", err)
+	}
+
+	replayed, err := tm.SubmitGameResult(t.Context(), id, "retry-1", m[0].ID, players, []string{"2:00.0", "2:15.0"})
+	if err != nil || replayed {
+		t.Errorf("unexpected resubmission of a voided game, expected a new result, got replayed %v, error %v", replayed, err)
+	}
+
+	saved, _ := repos.Results.GameResults(t.Context(), id)
+	if len(saved) != 2 {
+		t.Errorf("unexpected number of stored game results, expected 2, got %d", len(saved))
+	}
+}
+
+func TestManagerReplaysFinalResult(t *testing.T) {
+	tm, _, id := newTournament(t)
+
+	var last formats.Match
+	for tm.IsActive(id) {
+		m, _ := tm.GetNextMatches(id)
+		last = m[0]
+		if err := tm.HandleGameResult(t.Context(), id, last.ID, []string{last.Player1, last.Player2}, []string{"2:00.0", "2:15.0"}); err != nil {
+			t.Fatalf("failed to submit result for %v: %v", last.ID, err)
+		}
+	}
+
+	if err := tm.HandleGameResult(t.Context(), id, last.ID, []string{last.Player1, last.Player2}, []string{"2:00.0", "2:15.0"}); err != nil {
+		t.Errorf("unexpected error resubmitting the final game: %v", err)
+	}
+}
+
+func TestManagerLogsWithRequestID(t *testing.T) {
+	tm, _, id := newTournament(t)
+
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(logging.NewContextHandler(slog.NewTextHandler(&logs, nil))))
+
+	ctx := logging.WithRequestID(t.Context(), "req-1")
+	m, _ := tm.GetNextMatches(id)
+	if err := tm.HandleGameResult(ctx, id, m[0].ID, []string{m[0].Player1, m[0].Player2}, []string{"2:00.0", "2:15.0"}); err != nil {
+		t.Fatalf("failed to submit result: %v", err)
+	}
+
+	if err := tm.Forfeit(ctx, id, m[1].Player2, tournament.ForfeitAction, "no show"); err != nil {
+		t.Fatalf("failed to forfeit: %v", err)
+	}
+
+	// The lines come from both the manager and the format.
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		if !strings.Contains(line, "request_id=req-1") {
+			t.Errorf("expected every log line to carry the request ID, got %q", line)
+		}
+	}
+
+	if !strings.Contains(logs.String(), "tournament_id="+id) {
+		t.Errorf("expected the manager to log the forfeit, got %q", logs.String())
+	}
+}
@@ -0,0 +1,539 @@
+package tournament
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+	"tournament-manager/internal/tournament/formats"
+)
+
+// memoryStore keeps everything the Postgres repositories would, for tests
+// and local runs without a database.
+type memoryStore struct {
+	tournaments map[string]*Tournament
+	players     map[string][]Player
+	states      map[string][]byte
+	gameResults map[string][]formats.GameResult
+	submissions map[string][]memorySubmission
+	corrections map[string][]Correction
+	results     map[string]*TournamentResults
+	deadlines   map[string][]MatchDeadline
+	nextID      int
+	mu          sync.Mutex
+}
+
+type memorySubmission struct {
+	Submission
+	voided bool
+}
+
+type memoryTournaments struct{ *memoryStore }
+
+type memoryPlayers struct{ *memoryStore }
+
+type memoryResults struct{ *memoryStore }
+
+type memoryDeadlines struct{ *memoryStore }
+
+// NewMemoryRepositories returns repositories that share one in-memory store.
+func NewMemoryRepositories() Repositories {
+	store := &memoryStore{
+		tournaments: make(map[string]*Tournament),
+		players:     make(map[string][]Player),
+		states:      make(map[string][]byte),
+		gameResults: make(map[string][]formats.GameResult),
+		submissions: make(map[string][]memorySubmission),
+		corrections: make(map[string][]Correction),
+		results:     make(map[string]*TournamentResults),
+		deadlines:   make(map[string][]MatchDeadline),
+		nextID:      1,
+	}
+
+	return Repositories{
+		Tournaments: memoryTournaments{store},
+		Players:     memoryPlayers{store},
+		Results:     memoryResults{store},
+		Deadlines:   memoryDeadlines{store},
+	}
+}
+
+func (s *memoryStore) hasPlayer(tournamentID, ign string) bool {
+	return slices.ContainsFunc(s.players[tournamentID], func(p Player) bool { return p.IGN == ign })
+}
+
+func (r memoryTournaments) Create(ctx context.Context, name string, date uint64, format string, opts formats.Options, checkIn *CheckIn) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := strconv.Itoa(r.nextID)
+	r.nextID++
+
+	r.tournaments[id] = &Tournament{
+		ID:      id,
+		Name:    name,
+		Date:    date,
+		Format:  format,
+		Options: opts,
+		Status:  StatusScheduled,
+		CheckIn: checkIn,
+	}
+
+	return id, nil
+}
+
+func (r memoryTournaments) Get(ctx context.Context, tournamentID string) (*Tournament, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tournament, exists := r.tournaments[tournamentID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrTournamentNotFound, tournamentID)
+	}
+
+	copied := *tournament
+	return &copied, nil
+}
+
+func (r memoryTournaments) List(ctx context.Context, filter TournamentFilter) ([]TournamentSummary, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var summaries []TournamentSummary
+	for _, tournament := range r.tournaments {
+		date := time.Unix(int64(tournament.Date), 0)
+		if (filter.Status != "" && tournament.Status != filter.Status) ||
+			(filter.Format != "" && tournament.Format != filter.Format) ||
+			(filter.From != nil && date.Before(*filter.From)) ||
+			(filter.To != nil && date.After(*filter.To)) {
+			continue
+		}
+
+		players := 0
+		for _, player := range r.players[tournament.ID] {
+			if player.Status != PlayerWithdrawn && player.Status != PlayerDisqualified {
+				players++
+			}
+		}
+
+		summaries = append(summaries, TournamentSummary{
+			ID:          tournament.ID,
+			Name:        tournament.Name,
+			Date:        tournament.Date,
+			Format:      tournament.Format,
+			Status:      tournament.Status,
+			Players:     players,
+			Winner:      tournament.Winner,
+			CompletedAt: tournament.CompletedAt,
+		})
+	}
+
+	slices.SortFunc(summaries, func(a, b TournamentSummary) int {
+		order := cmp.Or(cmp.Compare(a.Date, b.Date), cmp.Compare(a.ID, b.ID))
+		if !filter.Ascending {
+			return -order
+		}
+		return order
+	})
+
+	total := len(summaries)
+	start := min(filter.Offset, total)
+	end := min(start+filter.Limit, total)
+	return summaries[start:end], total, nil
+}
+
+func (r memoryTournaments) SetStatus(ctx context.Context, tournamentID string, status string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tournament, exists := r.tournaments[tournamentID]
+	if !exists {
+		return fmt.Errorf("tournament %s not found", tournamentID)
+	}
+
+	tournament.Status = status
+	return nil
+}
+
+func (r memoryTournaments) Scheduled(ctx context.Context) ([]Tournament, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var tournaments []Tournament
+	for _, tournament := range r.tournaments {
+		if tournament.Status == StatusScheduled {
+			tournaments = append(tournaments, *tournament)
+		}
+	}
+
+	slices.SortFunc(tournaments, func(a, b Tournament) int {
+		return cmp.Or(cmp.Compare(a.Date, b.Date), cmp.Compare(a.ID, b.ID))
+	})
+	return tournaments, nil
+}
+
+func (r memoryTournaments) Start(ctx context.Context, tournamentID string, seeding []string, state []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tournament, exists := r.tournaments[tournamentID]
+	if !exists {
+		return fmt.Errorf("tournament %s not found", tournamentID)
+	}
+
+	tournament.Status = StatusInProgress
+	tournament.Seeding = slices.Clone(seeding)
+	r.states[tournamentID] = slices.Clone(state)
+	return nil
+}
+
+func (r memoryTournaments) SaveState(ctx context.Context, tournamentID string, state []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.states[tournamentID] = slices.Clone(state)
+	return nil
+}
+
+func (r memoryTournaments) DeleteState(ctx context.Context, tournamentID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.states, tournamentID)
+	return nil
+}
+
+func (r memoryTournaments) SavedStates(ctx context.Context) ([]SavedState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var states []SavedState
+	for tournamentID, data := range r.states {
+		states = append(states, SavedState{
+			TournamentID: tournamentID,
+			Format:       r.tournaments[tournamentID].Format,
+			Data:         slices.Clone(data),
+		})
+	}
+
+	return states, nil
+}
+
+func (r memoryPlayers) Create(ctx context.Context, tournamentID string, player Player) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tournaments[tournamentID]; !exists {
+		return fmt.Errorf("tournament %s not found", tournamentID)
+	}
+
+	r.players[tournamentID] = append(r.players[tournamentID], player)
+	return nil
+}
+
+func (r memoryPlayers) List(ctx context.Context, tournamentID string) ([]Player, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	players := slices.Clone(r.players[tournamentID])
+	sortBySeed(players)
+	return players, nil
+}
+
+func (r memoryPlayers) SeedOrder(ctx context.Context, tournamentID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	players := slices.DeleteFunc(slices.Clone(r.players[tournamentID]), func(p Player) bool {
+		return p.Status == PlayerWithdrawn || p.Status == PlayerDisqualified
+	})
+	sortBySeed(players)
+
+	igns := make([]string, len(players))
+	for i, player := range players {
+		igns[i] = player.IGN
+	}
+	return igns, nil
+}
+
+// sortBySeed orders players like the Postgres seed order: manual seeds first,
+// then by personal best and IGN.
+func sortBySeed(players []Player) {
+	slices.SortStableFunc(players, func(a, b Player) int {
+		if (a.Seed == nil) != (b.Seed == nil) {
+			if a.Seed != nil {
+				return -1
+			}
+			return 1
+		}
+
+		if a.Seed != nil && *a.Seed != *b.Seed {
+			return cmp.Compare(*a.Seed, *b.Seed)
+		}
+
+		return cmp.Or(cmp.Compare(a.PersonalBest, b.PersonalBest), cmp.Compare(a.IGN, b.IGN))
+	})
+}
+
+func (r memoryPlayers) SetSeeds(ctx context.Context, tournamentID string, igns []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ign := range igns {
+		if !r.hasPlayer(tournamentID, ign) {
+			return fmt.Errorf("player %s is not signed up for tournament %s", ign, tournamentID)
+		}
+	}
+
+	players := r.players[tournamentID]
+	for i := range players {
+		players[i].Seed = nil
+		if seed := slices.Index(igns, players[i].IGN); seed != -1 {
+			seed++
+			players[i].Seed = &seed
+		}
+	}
+
+	return nil
+}
+
+func (r memoryPlayers) SetStatus(ctx context.Context, tournamentID, ign, status string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.setPlayerStatus(tournamentID, ign, status)
+}
+
+// setPlayerStatus mirrors the Postgres function of the same name. The caller
+// holds the lock.
+func (s *memoryStore) setPlayerStatus(tournamentID, ign, status string) error {
+	players := s.players[tournamentID]
+	i := slices.IndexFunc(players, func(p Player) bool { return p.IGN == ign })
+	if i == -1 {
+		return fmt.Errorf("player %s is not signed up for tournament %s", ign, tournamentID)
+	}
+
+	players[i].Status = status
+	return nil
+}
+
+func (r memoryPlayers) CheckIn(ctx context.Context, tournamentID, name string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	players := r.players[tournamentID]
+	i := slices.IndexFunc(players, func(p Player) bool { return p.IGN == name })
+	if i == -1 {
+		i = slices.IndexFunc(players, func(p Player) bool { return p.DiscordName == name })
+	}
+
+	if i == -1 || players[i].Status == PlayerWithdrawn || players[i].Status == PlayerDisqualified {
+		return "", fmt.Errorf("player %s is not signed up for tournament %s", name, tournamentID)
+	}
+
+	players[i].CheckedIn = true
+	return players[i].IGN, nil
+}
+
+func (r memoryPlayers) CheckedIn(ctx context.Context, tournamentID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var igns []string
+	for _, player := range r.players[tournamentID] {
+		if player.CheckedIn {
+			igns = append(igns, player.IGN)
+		}
+	}
+	return igns, nil
+}
+
+func (r memoryResults) RecordGame(ctx context.Context, tournamentID string, submission Submission, results []formats.GameResult, state []byte, completed *TournamentResults) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, result := range results {
+		if !r.hasPlayer(tournamentID, result.Player) {
+			return fmt.Errorf("player %s is not signed up for tournament %s", result.Player, tournamentID)
+		}
+	}
+
+	if _, exists := r.tournaments[tournamentID]; !exists {
+		return fmt.Errorf("tournament %s not found", tournamentID)
+	}
+
+	if submission.Key != "" && slices.ContainsFunc(r.submissions[tournamentID], func(s memorySubmission) bool { return !s.voided && s.Key == submission.Key }) {
+		return fmt.Errorf("idempotency key %s has already been used", submission.Key)
+	}
+
+	if submission.Kind != "" {
+		status, err := forfeitPlayerStatus(submission.Kind)
+		if err != nil {
+			return err
+		}
+
+		if err := r.setPlayerStatus(tournamentID, submission.Players[0], status); err != nil {
+			return err
+		}
+	}
+
+	submission.ID = int64(r.nextID)
+	r.nextID++
+
+	r.gameResults[tournamentID] = append(r.gameResults[tournamentID], results...)
+	r.submissions[tournamentID] = append(r.submissions[tournamentID], memorySubmission{Submission: submission})
+	r.saveOutcome(tournamentID, state, completed)
+	return nil
+}
+
+// saveOutcome mirrors the Postgres function of the same name. The caller
+// holds the lock.
+func (s *memoryStore) saveOutcome(tournamentID string, state []byte, completed *TournamentResults) {
+	tournament := s.tournaments[tournamentID]
+	if completed == nil {
+		tournament.Status = StatusInProgress
+		tournament.Winner = ""
+		tournament.CompletedAt = nil
+		s.states[tournamentID] = slices.Clone(state)
+		return
+	}
+
+	final := *completed
+	final.Name = tournament.Name
+	final.Format = tournament.Format
+	final.CompletedAt = time.Now()
+	s.results[tournamentID] = &final
+	tournament.Status = StatusCompleted
+	tournament.Winner = final.Winner
+	tournament.CompletedAt = &final.CompletedAt
+	delete(s.states, tournamentID)
+}
+
+func (r memoryResults) FindSubmission(ctx context.Context, tournamentID, key, gameID string) (*Submission, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	submissions := r.submissions[tournamentID]
+	for i := len(submissions) - 1; i >= 0; i-- {
+		submission := submissions[i]
+		if !submission.voided && ((key != "" && submission.Key == key) || (key == "" && submission.GameID == gameID)) {
+			found := submission.Submission
+			return &found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r memoryResults) Submissions(ctx context.Context, tournamentID string) ([]Submission, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var submissions []Submission
+	for _, submission := range r.submissions[tournamentID] {
+		if !submission.voided {
+			submissions = append(submissions, submission.Submission)
+		}
+	}
+
+	return submissions, nil
+}
+
+func (r memoryResults) Correct(ctx context.Context, tournamentID string, corrections []Correction, results []formats.GameResult, state []byte, completed *TournamentResults) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, result := range results {
+		if !r.hasPlayer(tournamentID, result.Player) {
+			return fmt.Errorf("player %s is not signed up for tournament %s", result.Player, tournamentID)
+		}
+	}
+
+	submissions := r.submissions[tournamentID]
+	indexes := make([]int, len(corrections))
+	for c, correction := range corrections {
+		indexes[c] = slices.IndexFunc(submissions, func(s memorySubmission) bool { return s.ID == correction.Before.ID })
+		if indexes[c] == -1 {
+			return fmt.Errorf("submission %d not found", correction.Before.ID)
+		}
+	}
+
+	for c, correction := range corrections {
+		i := indexes[c]
+		if correction.After != nil {
+			submissions[i].Players = slices.Clone(correction.After.Players)
+			submissions[i].Times = slices.Clone(correction.After.Times)
+		} else {
+			submissions[i].voided = true
+			if correction.Before.Kind != "" {
+				r.setPlayerStatus(tournamentID, correction.Before.Players[0], PlayerRegistered)
+			}
+		}
+	}
+
+	r.corrections[tournamentID] = append(r.corrections[tournamentID], corrections...)
+	r.gameResults[tournamentID] = slices.Clone(results)
+	delete(r.results, tournamentID)
+	r.saveOutcome(tournamentID, state, completed)
+	return nil
+}
+
+func (r memoryResults) GameResults(ctx context.Context, tournamentID string) ([]formats.GameResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.gameResults[tournamentID]), nil
+}
+
+func (r memoryResults) Get(ctx context.Context, tournamentID string) (*TournamentResults, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results, exists := r.results[tournamentID]
+	if !exists {
+		return nil, fmt.Errorf("tournament %s has not completed", tournamentID)
+	}
+
+	copied := *results
+	return &copied, nil
+}
+
+func (r memoryDeadlines) List(ctx context.Context, tournamentID string) ([]MatchDeadline, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deadlines := slices.Clone(r.deadlines[tournamentID])
+	for i := range deadlines {
+		deadlines[i].Reported = slices.Clone(deadlines[i].Reported)
+	}
+	return deadlines, nil
+}
+
+func (r memoryDeadlines) Save(ctx context.Context, tournamentID string, deadline MatchDeadline) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deadline.Reported = slices.Clone(deadline.Reported)
+
+	deadlines := r.deadlines[tournamentID]
+	if i := slices.IndexFunc(deadlines, func(d MatchDeadline) bool { return d.MatchID == deadline.MatchID }); i != -1 {
+		deadlines[i] = deadline
+		return nil
+	}
+
+	r.deadlines[tournamentID] = append(deadlines, deadline)
+	return nil
+}
+
+func (r memoryDeadlines) Delete(ctx context.Context, tournamentID string, matchIDs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deadlines[tournamentID] = slices.DeleteFunc(r.deadlines[tournamentID], func(d MatchDeadline) bool {
+		return slices.Contains(matchIDs, d.MatchID)
+	})
+	return nil
+}
@@ -2,22 +2,43 @@ package tournament
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
-	"tournament-manager/internal/database"
 )
 
 type Player struct {
 	IGN          string
 	DiscordName  string
 	PersonalBest int64
+	Seed         *int
+	// Status is one of the Player statuses, empty for a registered player.
+	Status    string
+	CheckedIn bool
 }
 
-func Signup(ign string, discord string, pb uint64, tournament_id string) error {
-	slog.Debug("inserting values", "ign", ign, "discord", discord, "pb", pb, "tournament_id", tournament_id)
-	insertQuery := "INSERT INTO Player (ign, discord_name, personal_best, tournament_id) VALUES ($1, $2, $3, $4)"
+func (tm *TournamentManager) Signup(ctx context.Context, ign string, discord string, pb uint64, tournament_id string) error {
+	slog.DebugContext(ctx, "inserting values", "ign", ign, "discord", discord, "pb", pb, "tournament_id", tournament_id)
 
-	if _, err := database.DB.Exec(context.Background(), insertQuery, ign, discord, pb, tournament_id); err != nil {
-		slog.Warn(err.Error())
+	player := Player{IGN: ign, DiscordName: discord, PersonalBest: int64(pb)}
+	if err := tm.players.Create(ctx, tournament_id, player); err != nil {
+		slog.WarnContext(ctx, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// SetSeeds seeds the given players in order, starting at 1. Players left out
+// lose any manual seed and are seeded by personal best behind them.
+func (tm *TournamentManager) SetSeeds(ctx context.Context, tournamentID string, igns []string) error {
+	slog.DebugContext(ctx, "setting seeds", "tournament_id", tournamentID, "igns", igns)
+
+	if tm.IsActive(tournamentID) {
+		return fmt.Errorf("tournament %s has already started", tournamentID)
+	}
+
+	if err := tm.players.SetSeeds(ctx, tournamentID, igns); err != nil {
+		slog.WarnContext(ctx, err.Error())
 		return err
 	}
 
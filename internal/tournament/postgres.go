@@ -0,0 +1,868 @@
+package tournament
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"tournament-manager/internal/tournament/formats"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// execer is satisfied by both the pool and a transaction, so statements
+// shared by several repositories can run in either.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type postgresTournaments struct {
+	pool *pgxpool.Pool
+}
+
+type postgresPlayers struct {
+	pool *pgxpool.Pool
+}
+
+type postgresResults struct {
+	pool *pgxpool.Pool
+}
+
+type postgresDeadlines struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresRepositories returns repositories backed by the given pool.
+func NewPostgresRepositories(pool *pgxpool.Pool) Repositories {
+	return Repositories{
+		Tournaments: &postgresTournaments{pool: pool},
+		Players:     &postgresPlayers{pool: pool},
+		Results:     &postgresResults{pool: pool},
+		Deadlines:   &postgresDeadlines{pool: pool},
+	}
+}
+
+func (r *postgresTournaments) Create(ctx context.Context, name string, date uint64, format string, opts formats.Options, checkIn *CheckIn) (string, error) {
+	insertQuery := `
+		INSERT INTO Tournament (name, date, format, options, checkin_opens, checkin_closes, start_when_checkin_closes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+	`
+
+	var opens, closes *int64
+	startWhenClosed := false
+	if checkIn != nil {
+		opensSeconds, closesSeconds := int64(checkIn.Opens/time.Second), int64(checkIn.Closes/time.Second)
+		opens, closes = &opensSeconds, &closesSeconds
+		startWhenClosed = checkIn.StartWhenClosed
+	}
+
+	var id string
+	if err := r.pool.QueryRow(ctx, insertQuery, name, date, format, opts, opens, closes, startWhenClosed).Scan(&id); err != nil {
+		return "", fmt.Errorf("failed to create tournament: %w", err)
+	}
+
+	return id, nil
+}
+
+const tournamentColumns = `
+	id, name, date, format, options, status, COALESCE(seeding, '{}'),
+	checkin_opens, checkin_closes, start_when_checkin_closes, COALESCE(winner, ''), completed_at
+`
+
+func scanTournament(row pgx.Row) (*Tournament, error) {
+	var tournament Tournament
+	var opens, closes *int64
+	var startWhenClosed bool
+	err := row.Scan(&tournament.ID, &tournament.Name, &tournament.Date, &tournament.Format, &tournament.Options, &tournament.Status, &tournament.Seeding,
+		&opens, &closes, &startWhenClosed, &tournament.Winner, &tournament.CompletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTournamentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan tournament: %w", err)
+	}
+
+	if opens != nil && closes != nil {
+		tournament.CheckIn = &CheckIn{
+			Opens:           time.Duration(*opens) * time.Second,
+			Closes:          time.Duration(*closes) * time.Second,
+			StartWhenClosed: startWhenClosed,
+		}
+	}
+
+	return &tournament, nil
+}
+
+func (r *postgresTournaments) Get(ctx context.Context, tournamentID string) (*Tournament, error) {
+	// An ID that isn't a UUID can't match a tournament, and would otherwise
+	// fail to encode as a query argument.
+	var id pgtype.UUID
+	if err := id.Scan(tournamentID); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTournamentNotFound, tournamentID)
+	}
+
+	query := "SELECT " + tournamentColumns + " FROM Tournament WHERE id = $1"
+	tournament, err := scanTournament(r.pool.QueryRow(ctx, query, tournamentID))
+	if errors.Is(err, ErrTournamentNotFound) {
+		return nil, fmt.Errorf("%w: %s", err, tournamentID)
+	}
+	return tournament, err
+}
+
+func (r *postgresTournaments) List(ctx context.Context, filter TournamentFilter) ([]TournamentSummary, int, error) {
+	var conditions []string
+	var args []any
+	where := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Status != "" {
+		where("t.status = $%d", filter.Status)
+	}
+	if filter.Format != "" {
+		where("t.format = $%d", filter.Format)
+	}
+	if filter.From != nil {
+		where("t.date >= $%d", filter.From.Unix())
+	}
+	if filter.To != nil {
+		where("t.date <= $%d", filter.To.Unix())
+	}
+
+	whereSQL := ""
+	if len(conditions) > 0 {
+		whereSQL = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, "SELECT COUNT(*) FROM Tournament t "+whereSQL, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count tournaments: %w", err)
+	}
+
+	order := "DESC"
+	if filter.Ascending {
+		order = "ASC"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT t.id, t.name, t.date, t.format, t.status, COALESCE(t.winner, ''), t.completed_at,
+			(SELECT COUNT(*) FROM Player p WHERE p.tournament_id = t.id AND p.status NOT IN ($%d, $%d))
+		FROM Tournament t
+		%s
+		ORDER BY t.date %s, t.id %s
+		LIMIT $%d OFFSET $%d
+	`, len(args)+1, len(args)+2, whereSQL, order, order, len(args)+3, len(args)+4)
+	args = append(args, PlayerWithdrawn, PlayerDisqualified, filter.Limit, filter.Offset)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query tournaments: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := []TournamentSummary{}
+	for rows.Next() {
+		var summary TournamentSummary
+		if err := rows.Scan(&summary.ID, &summary.Name, &summary.Date, &summary.Format, &summary.Status, &summary.Winner, &summary.CompletedAt, &summary.Players); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan tournament: %w", err)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating tournaments: %w", err)
+	}
+
+	return summaries, total, nil
+}
+
+func (r *postgresTournaments) Scheduled(ctx context.Context) ([]Tournament, error) {
+	query := "SELECT " + tournamentColumns + " FROM Tournament WHERE status = $1 AND auto_start ORDER BY date, id"
+	rows, err := r.pool.Query(ctx, query, StatusScheduled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduled tournaments: %w", err)
+	}
+	defer rows.Close()
+
+	var tournaments []Tournament
+	for rows.Next() {
+		tournament, err := scanTournament(rows)
+		if err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, *tournament)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating scheduled tournaments: %w", err)
+	}
+
+	return tournaments, nil
+}
+
+func (r *postgresTournaments) SetStatus(ctx context.Context, tournamentID string, status string) error {
+	sql := "UPDATE Tournament SET status = $1 WHERE id = $2"
+	if _, err := r.pool.Exec(ctx, sql, status, tournamentID); err != nil {
+		return fmt.Errorf("failed to set tournament status to %s: %w", status, err)
+	}
+
+	return nil
+}
+
+func (r *postgresTournaments) Start(ctx context.Context, tournamentID string, seeding []string, state []byte) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sql := "UPDATE Tournament SET status = $1, seeding = $2 WHERE id = $3"
+	if _, err := tx.Exec(ctx, sql, StatusInProgress, seeding, tournamentID); err != nil {
+		return fmt.Errorf("failed to start tournament: %w", err)
+	}
+
+	if err := saveState(ctx, tx, tournamentID, state); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresTournaments) SaveState(ctx context.Context, tournamentID string, state []byte) error {
+	return saveState(ctx, r.pool, tournamentID, state)
+}
+
+func (r *postgresTournaments) DeleteState(ctx context.Context, tournamentID string) error {
+	return deleteState(ctx, r.pool, tournamentID)
+}
+
+func saveState(ctx context.Context, db execer, tournamentID string, state []byte) error {
+	sql := `
+		INSERT INTO TournamentState (tournament_id, state, updated_at)
+		VALUES ($1, $2, now())
+		ON CONFLICT (tournament_id) DO UPDATE SET state = EXCLUDED.state, updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := db.Exec(ctx, sql, tournamentID, state); err != nil {
+		return fmt.Errorf("failed to save tournament state: %w", err)
+	}
+
+	return nil
+}
+
+func deleteState(ctx context.Context, db execer, tournamentID string) error {
+	sql := "DELETE FROM TournamentState WHERE tournament_id = $1"
+	if _, err := db.Exec(ctx, sql, tournamentID); err != nil {
+		return fmt.Errorf("failed to delete tournament state: %w", err)
+	}
+
+	return nil
+}
+
+func (r *postgresTournaments) SavedStates(ctx context.Context) ([]SavedState, error) {
+	query := `
+		SELECT s.tournament_id, t.format, s.state
+		FROM TournamentState s
+		JOIN Tournament t ON t.id = s.tournament_id
+	`
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tournament states: %w", err)
+	}
+	defer rows.Close()
+
+	var states []SavedState
+	for rows.Next() {
+		var state SavedState
+		if err := rows.Scan(&state.TournamentID, &state.Format, &state.Data); err != nil {
+			return nil, fmt.Errorf("failed to scan tournament state: %w", err)
+		}
+		states = append(states, state)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tournament states: %w", err)
+	}
+
+	return states, nil
+}
+
+func (r *postgresPlayers) Create(ctx context.Context, tournamentID string, player Player) error {
+	insertQuery := "INSERT INTO Player (ign, discord_name, personal_best, tournament_id) VALUES ($1, $2, $3, $4)"
+
+	if _, err := r.pool.Exec(ctx, insertQuery, player.IGN, player.DiscordName, player.PersonalBest, tournamentID); err != nil {
+		return fmt.Errorf("failed to sign up player %s: %w", player.IGN, err)
+	}
+
+	return nil
+}
+
+func (r *postgresPlayers) List(ctx context.Context, tournamentID string) ([]Player, error) {
+	query := `
+		SELECT ign, discord_name, COALESCE(personal_best, 0), seed,
+			COALESCE(NULLIF(status, 'registered'), ''), checked_in_at IS NOT NULL
+		FROM Player
+		WHERE tournament_id = $1
+		ORDER BY seed ASC NULLS LAST, personal_best ASC NULLS LAST, ign ASC
+	`
+	rows, err := r.pool.Query(ctx, query, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query players: %w", err)
+	}
+	defer rows.Close()
+
+	var players []Player
+	for rows.Next() {
+		var player Player
+		if err := rows.Scan(&player.IGN, &player.DiscordName, &player.PersonalBest, &player.Seed, &player.Status, &player.CheckedIn); err != nil {
+			return nil, fmt.Errorf("failed to scan player: %w", err)
+		}
+		players = append(players, player)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating players: %w", err)
+	}
+
+	return players, nil
+}
+
+func (r *postgresPlayers) SeedOrder(ctx context.Context, tournamentID string) ([]string, error) {
+	query := `
+		SELECT ign FROM Player
+		WHERE tournament_id = $1 AND status NOT IN ($2, $3)
+		ORDER BY seed ASC NULLS LAST, personal_best ASC NULLS LAST, ign ASC
+	`
+	rows, err := r.pool.Query(ctx, query, tournamentID, PlayerWithdrawn, PlayerDisqualified)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query players: %w", err)
+	}
+	defer rows.Close()
+
+	var players []string
+	for rows.Next() {
+		var ign string
+		if err := rows.Scan(&ign); err != nil {
+			return nil, fmt.Errorf("failed to scan player: %w", err)
+		}
+		players = append(players, ign)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating players: %w", err)
+	}
+
+	return players, nil
+}
+
+func (r *postgresPlayers) SetSeeds(ctx context.Context, tournamentID string, igns []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "UPDATE Player SET seed = NULL WHERE tournament_id = $1", tournamentID); err != nil {
+		return fmt.Errorf("failed to clear seeds: %w", err)
+	}
+
+	updateQuery := "UPDATE Player SET seed = $1 WHERE tournament_id = $2 AND ign = $3"
+	for i, ign := range igns {
+		tag, err := tx.Exec(ctx, updateQuery, i+1, tournamentID, ign)
+		if err != nil {
+			return fmt.Errorf("failed to seed player %s: %w", ign, err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("player %s is not signed up for tournament %s", ign, tournamentID)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *postgresPlayers) SetStatus(ctx context.Context, tournamentID, ign, status string) error {
+	return setPlayerStatus(ctx, r.pool, tournamentID, ign, status)
+}
+
+func setPlayerStatus(ctx context.Context, db execer, tournamentID, ign, status string) error {
+	tag, err := db.Exec(ctx, "UPDATE Player SET status = $1 WHERE tournament_id = $2 AND ign = $3", status, tournamentID, ign)
+	if err != nil {
+		return fmt.Errorf("failed to set status of player %s: %w", ign, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("player %s is not signed up for tournament %s", ign, tournamentID)
+	}
+
+	return nil
+}
+
+func (r *postgresPlayers) CheckIn(ctx context.Context, tournamentID, name string) (string, error) {
+	// An IGN match wins over a Discord name match, in case a player's
+	// Discord name is someone else's IGN.
+	query := `
+		UPDATE Player SET checked_in_at = COALESCE(checked_in_at, now())
+		WHERE id = (
+			SELECT id FROM Player
+			WHERE tournament_id = $1 AND (ign = $2 OR discord_name = $2) AND status NOT IN ($3, $4)
+			ORDER BY ign = $2 DESC
+			LIMIT 1
+		)
+		RETURNING ign
+	`
+
+	var ign string
+	err := r.pool.QueryRow(ctx, query, tournamentID, name, PlayerWithdrawn, PlayerDisqualified).Scan(&ign)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("player %s is not signed up for tournament %s", name, tournamentID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to check in player %s: %w", name, err)
+	}
+
+	return ign, nil
+}
+
+func (r *postgresPlayers) CheckedIn(ctx context.Context, tournamentID string) ([]string, error) {
+	query := "SELECT ign FROM Player WHERE tournament_id = $1 AND checked_in_at IS NOT NULL"
+	rows, err := r.pool.Query(ctx, query, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query checked in players: %w", err)
+	}
+
+	defer rows.Close()
+
+	var igns []string
+	for rows.Next() {
+		var ign string
+		if err := rows.Scan(&ign); err != nil {
+			return nil, fmt.Errorf("failed to scan player: %w", err)
+		}
+		igns = append(igns, ign)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating players: %w", err)
+	}
+
+	return igns, nil
+}
+
+func (r *postgresResults) RecordGame(ctx context.Context, tournamentID string, submission Submission, results []formats.GameResult, state []byte, completed *TournamentResults) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := saveGameResults(ctx, tx, tournamentID, results); err != nil {
+		return err
+	}
+
+	if err := saveSubmission(ctx, tx, tournamentID, submission); err != nil {
+		return err
+	}
+
+	if submission.Kind != "" {
+		status, err := forfeitPlayerStatus(submission.Kind)
+		if err != nil {
+			return err
+		}
+
+		if err := setPlayerStatus(ctx, tx, tournamentID, submission.Players[0], status); err != nil {
+			return err
+		}
+	}
+
+	if err := saveOutcome(ctx, tx, tournamentID, state, completed); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func saveGameResults(ctx context.Context, tx pgx.Tx, tournamentID string, results []formats.GameResult) error {
+	sql := `
+		INSERT INTO GameResult (game_id, tournament_id, player_id, position, time)
+		SELECT $1, $2, id, $4, $5 FROM Player WHERE tournament_id = $2 AND ign = $3
+	`
+	for _, result := range results {
+		tag, err := tx.Exec(ctx, sql, result.GameID, tournamentID, result.Player, result.Position, result.Time)
+		if err != nil {
+			return fmt.Errorf("failed to save game result for game %v: %w", result, err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("player %s is not signed up for tournament %s", result.Player, tournamentID)
+		}
+	}
+
+	return nil
+}
+
+// saveOutcome saves the state of a tournament after a game or, if the game
+// completed it, its final results in place of the state.
+func saveOutcome(ctx context.Context, tx pgx.Tx, tournamentID string, state []byte, completed *TournamentResults) error {
+	if completed == nil {
+		return saveState(ctx, tx, tournamentID, state)
+	}
+
+	if err := complete(ctx, tx, *completed); err != nil {
+		return err
+	}
+
+	return deleteState(ctx, tx, tournamentID)
+}
+
+func (r *postgresResults) GameResults(ctx context.Context, tournamentID string) ([]formats.GameResult, error) {
+	query := `
+		SELECT g.game_id, p.ign, g.position, g.time
+		FROM GameResult g
+		JOIN Player p ON p.id = g.player_id
+		WHERE g.tournament_id = $1
+		ORDER BY g.id
+	`
+	rows, err := r.pool.Query(ctx, query, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query game results: %w", err)
+	}
+	defer rows.Close()
+
+	var results []formats.GameResult
+	for rows.Next() {
+		var result formats.GameResult
+		if err := rows.Scan(&result.GameID, &result.Player, &result.Position, &result.Time); err != nil {
+			return nil, fmt.Errorf("failed to scan game result: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating game results: %w", err)
+	}
+
+	return results, nil
+}
+
+func saveSubmission(ctx context.Context, tx pgx.Tx, tournamentID string, submission Submission) error {
+	var key *string
+	if submission.Key != "" {
+		key = &submission.Key
+	}
+
+	kind := submission.Kind
+	if kind == "" {
+		kind = "result"
+	}
+
+	sql := `
+		INSERT INTO ResultSubmission (tournament_id, kind, game_id, idempotency_key, players, times)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	if _, err := tx.Exec(ctx, sql, tournamentID, kind, submission.GameID, key, submission.Players, submission.Times); err != nil {
+		return fmt.Errorf("failed to save submission: %w", err)
+	}
+
+	return nil
+}
+
+func (r *postgresResults) FindSubmission(ctx context.Context, tournamentID, key, gameID string) (*Submission, error) {
+	query := `
+		SELECT id, COALESCE(NULLIF(kind, 'result'), ''), game_id, COALESCE(idempotency_key, ''), players, times FROM ResultSubmission
+		WHERE tournament_id = $1 AND idempotency_key = $2 AND voided_at IS NULL
+	`
+	args := []any{tournamentID, key}
+	if key == "" {
+		query = `
+			SELECT id, COALESCE(NULLIF(kind, 'result'), ''), game_id, COALESCE(idempotency_key, ''), players, times FROM ResultSubmission
+			WHERE tournament_id = $1 AND game_id = $2 AND voided_at IS NULL
+			ORDER BY id DESC LIMIT 1
+		`
+		args = []any{tournamentID, gameID}
+	}
+
+	var submission Submission
+	err := r.pool.QueryRow(ctx, query, args...).Scan(&submission.ID, &submission.Kind, &submission.GameID, &submission.Key, &submission.Players, &submission.Times)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find submission: %w", err)
+	}
+
+	return &submission, nil
+}
+
+func (r *postgresResults) Submissions(ctx context.Context, tournamentID string) ([]Submission, error) {
+	query := `
+		SELECT id, COALESCE(NULLIF(kind, 'result'), ''), game_id, COALESCE(idempotency_key, ''), players, times FROM ResultSubmission
+		WHERE tournament_id = $1 AND voided_at IS NULL
+		ORDER BY id
+	`
+	rows, err := r.pool.Query(ctx, query, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query submissions: %w", err)
+	}
+	defer rows.Close()
+
+	var submissions []Submission
+	for rows.Next() {
+		var submission Submission
+		if err := rows.Scan(&submission.ID, &submission.Kind, &submission.GameID, &submission.Key, &submission.Players, &submission.Times); err != nil {
+			return nil, fmt.Errorf("failed to scan submission: %w", err)
+		}
+		submissions = append(submissions, submission)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating submissions: %w", err)
+	}
+
+	return submissions, nil
+}
+
+func (r *postgresResults) Correct(ctx context.Context, tournamentID string, corrections []Correction, results []formats.GameResult, state []byte, completed *TournamentResults) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	auditSQL := `
+		INSERT INTO ResultCorrection (tournament_id, submission_id, game_id, action, reason, players_before, times_before, players_after, times_after)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	for _, correction := range corrections {
+		before := correction.Before
+		var playersAfter []string
+		var timesAfter []uint64
+
+		if correction.After != nil {
+			playersAfter, timesAfter = correction.After.Players, correction.After.Times
+			sql := "UPDATE ResultSubmission SET players = $1, times = $2 WHERE id = $3"
+			if _, err := tx.Exec(ctx, sql, playersAfter, timesAfter, before.ID); err != nil {
+				return fmt.Errorf("failed to amend submission %d: %w", before.ID, err)
+			}
+		} else {
+			sql := "UPDATE ResultSubmission SET voided_at = now() WHERE id = $1"
+			if _, err := tx.Exec(ctx, sql, before.ID); err != nil {
+				return fmt.Errorf("failed to void submission %d: %w", before.ID, err)
+			}
+
+			if before.Kind != "" {
+				if err := setPlayerStatus(ctx, tx, tournamentID, before.Players[0], PlayerRegistered); err != nil {
+					return err
+				}
+			}
+		}
+
+		if _, err := tx.Exec(ctx, auditSQL, tournamentID, before.ID, before.GameID, correction.Action, correction.Reason, before.Players, before.Times, playersAfter, timesAfter); err != nil {
+			return fmt.Errorf("failed to record correction of game %s: %w", before.GameID, err)
+		}
+	}
+
+	// The game results are replaced rather than patched, since a game ID
+	// without a game number can stand for several games of a series.
+	if _, err := tx.Exec(ctx, "DELETE FROM GameResult WHERE tournament_id = $1", tournamentID); err != nil {
+		return fmt.Errorf("failed to clear game results: %w", err)
+	}
+
+	if err := saveGameResults(ctx, tx, tournamentID, results); err != nil {
+		return err
+	}
+
+	// Whatever the outcome, the tournament's previous final results no
+	// longer hold.
+	for _, sql := range []string{"DELETE FROM MatchRecord WHERE tournament_id = $1", "DELETE FROM Placement WHERE tournament_id = $1"} {
+		if _, err := tx.Exec(ctx, sql, tournamentID); err != nil {
+			return fmt.Errorf("failed to clear tournament results: %w", err)
+		}
+	}
+
+	reopenSQL := "UPDATE Tournament SET status = $1, winner = NULL, completed_at = NULL WHERE id = $2"
+	if _, err := tx.Exec(ctx, reopenSQL, StatusInProgress, tournamentID); err != nil {
+		return fmt.Errorf("failed to reopen tournament: %w", err)
+	}
+
+	if err := saveOutcome(ctx, tx, tournamentID, state, completed); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// complete saves the matches and placements of a completed tournament and
+// marks it as completed.
+func complete(ctx context.Context, tx pgx.Tx, results TournamentResults) error {
+	slog.Debug("Saving tournament results", "tournament_id", results.TournamentID, "winner", results.Winner)
+
+	matchSQL := `
+		INSERT INTO MatchRecord (tournament_id, match_id, round, bracket, players, winner, best_of)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (tournament_id, match_id) DO NOTHING
+	`
+	for _, match := range results.Matches {
+		if _, err := tx.Exec(ctx, matchSQL, results.TournamentID, match.MatchID, match.Round, match.Bracket, match.Players, match.Winner, match.BestOf); err != nil {
+			return fmt.Errorf("failed to save match %s: %w", match.MatchID, err)
+		}
+	}
+
+	placementSQL := `
+		INSERT INTO Placement (tournament_id, player_id, position)
+		SELECT $1, id, $3 FROM Player WHERE tournament_id = $1 AND ign = $2
+		ON CONFLICT (tournament_id, player_id) DO UPDATE SET position = EXCLUDED.position
+	`
+	for _, placement := range results.Placements {
+		if _, err := tx.Exec(ctx, placementSQL, results.TournamentID, placement.Player, placement.Position); err != nil {
+			return fmt.Errorf("failed to save placement of %s: %w", placement.Player, err)
+		}
+	}
+
+	tournamentSQL := "UPDATE Tournament SET status = $1, winner = $2, completed_at = now() WHERE id = $3"
+	if _, err := tx.Exec(ctx, tournamentSQL, StatusCompleted, results.Winner, results.TournamentID); err != nil {
+		return fmt.Errorf("failed to mark tournament as completed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *postgresResults) Get(ctx context.Context, tournamentID string) (*TournamentResults, error) {
+
+	var results TournamentResults
+	var status string
+	var winner *string
+	var completedAt *time.Time
+
+	query := "SELECT id, name, format, status, winner, completed_at FROM Tournament WHERE id = $1"
+	row := r.pool.QueryRow(ctx, query, tournamentID)
+	if err := row.Scan(&results.TournamentID, &results.Name, &results.Format, &status, &winner, &completedAt); err != nil {
+		return nil, fmt.Errorf("failed to get tournament %s: %w", tournamentID, err)
+	}
+
+	if status != StatusCompleted || winner == nil || completedAt == nil {
+		return nil, fmt.Errorf("tournament %s has not completed", tournamentID)
+	}
+	results.Winner = *winner
+	results.CompletedAt = *completedAt
+
+	placementQuery := `
+		SELECT p.position, pl.ign
+		FROM Placement p
+		JOIN Player pl ON pl.id = p.player_id
+		WHERE p.tournament_id = $1
+		ORDER BY p.position, pl.ign
+	`
+	rows, err := r.pool.Query(ctx, placementQuery, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query placements: %w", err)
+	}
+	defer rows.Close()
+
+	results.Placements = []formats.Placement{}
+	for rows.Next() {
+		var placement formats.Placement
+		if err := rows.Scan(&placement.Position, &placement.Player); err != nil {
+			return nil, fmt.Errorf("failed to scan placement: %w", err)
+		}
+		results.Placements = append(results.Placements, placement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating placements: %w", err)
+	}
+
+	matchQuery := `
+		SELECT match_id, round, COALESCE(bracket, ''), players, COALESCE(winner, ''), best_of
+		FROM MatchRecord
+		WHERE tournament_id = $1
+		ORDER BY round, length(match_id), match_id
+	`
+	matchRows, err := r.pool.Query(ctx, matchQuery, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query matches: %w", err)
+	}
+	defer matchRows.Close()
+
+	results.Matches = []MatchRecord{}
+	for matchRows.Next() {
+		var match MatchRecord
+		if err := matchRows.Scan(&match.MatchID, &match.Round, &match.Bracket, &match.Players, &match.Winner, &match.BestOf); err != nil {
+			return nil, fmt.Errorf("failed to scan match: %w", err)
+		}
+		results.Matches = append(results.Matches, match)
+	}
+
+	if err := matchRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating matches: %w", err)
+	}
+
+	return &results, nil
+}
+
+func (r *postgresDeadlines) List(ctx context.Context, tournamentID string) ([]MatchDeadline, error) {
+	query := `
+		SELECT match_id, round, opened_at, deadline, overdue, auto_forfeit, reported
+		FROM MatchDeadline
+		WHERE tournament_id = $1
+		ORDER BY deadline, match_id
+	`
+	rows, err := r.pool.Query(ctx, query, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query match deadlines: %w", err)
+	}
+	defer rows.Close()
+
+	var deadlines []MatchDeadline
+	for rows.Next() {
+		var deadline MatchDeadline
+		if err := rows.Scan(&deadline.MatchID, &deadline.Round, &deadline.OpenedAt, &deadline.Deadline, &deadline.Overdue, &deadline.AutoForfeit, &deadline.Reported); err != nil {
+			return nil, fmt.Errorf("failed to scan match deadline: %w", err)
+		}
+		deadlines = append(deadlines, deadline)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating match deadlines: %w", err)
+	}
+
+	return deadlines, nil
+}
+
+func (r *postgresDeadlines) Save(ctx context.Context, tournamentID string, deadline MatchDeadline) error {
+	reported := deadline.Reported
+	if reported == nil {
+		reported = []string{}
+	}
+
+	sql := `
+		INSERT INTO MatchDeadline (tournament_id, match_id, round, opened_at, deadline, overdue, auto_forfeit, reported)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (tournament_id, match_id) DO UPDATE SET
+			deadline = EXCLUDED.deadline,
+			overdue = EXCLUDED.overdue,
+			auto_forfeit = EXCLUDED.auto_forfeit,
+			reported = EXCLUDED.reported
+	`
+	_, err := r.pool.Exec(ctx, sql, tournamentID, deadline.MatchID, deadline.Round, deadline.OpenedAt, deadline.Deadline, deadline.Overdue, deadline.AutoForfeit, reported)
+	if err != nil {
+		return fmt.Errorf("failed to save deadline of match %s: %w", deadline.MatchID, err)
+	}
+
+	return nil
+}
+
+func (r *postgresDeadlines) Delete(ctx context.Context, tournamentID string, matchIDs []string) error {
+	sql := "DELETE FROM MatchDeadline WHERE tournament_id = $1 AND match_id = ANY($2)"
+	if _, err := r.pool.Exec(ctx, sql, tournamentID, matchIDs); err != nil {
+		return fmt.Errorf("failed to delete match deadlines: %w", err)
+	}
+
+	return nil
+}
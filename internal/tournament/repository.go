@@ -0,0 +1,112 @@
+package tournament
+
+import (
+	"context"
+	"tournament-manager/internal/tournament/formats"
+)
+
+// TournamentRepository stores tournaments and the saved state of the ones in
+// progress.
+type TournamentRepository interface {
+	Create(ctx context.Context, name string, date uint64, format string, opts formats.Options, checkIn *CheckIn) (string, error)
+	// Get returns a tournament without its participants, or an error
+	// wrapping ErrTournamentNotFound.
+	Get(ctx context.Context, tournamentID string) (*Tournament, error)
+	// List returns one page of the tournaments matching filter, whose
+	// Limit is set, and the total number of matches.
+	List(ctx context.Context, filter TournamentFilter) ([]TournamentSummary, int, error)
+	SetStatus(ctx context.Context, tournamentID string, status string) error
+	// Scheduled returns the tournaments that have not started yet and are
+	// due to start on their own, which excludes the ones that were already
+	// past their date when scheduled starts were introduced.
+	Scheduled(ctx context.Context) ([]Tournament, error)
+	// Start marks a tournament as in progress, remembering the seeding it
+	// started with, and saves its first state.
+	Start(ctx context.Context, tournamentID string, seeding []string, state []byte) error
+
+	SaveState(ctx context.Context, tournamentID string, state []byte) error
+	DeleteState(ctx context.Context, tournamentID string) error
+	// SavedStates returns the saved state of every tournament in progress.
+	SavedStates(ctx context.Context) ([]SavedState, error)
+}
+
+// PlayerRepository stores the players signed up for a tournament.
+type PlayerRepository interface {
+	Create(ctx context.Context, tournamentID string, player Player) error
+	// List returns every player signed up for a tournament in seed order,
+	// including those who withdrew or were disqualified.
+	List(ctx context.Context, tournamentID string) ([]Player, error)
+	// SeedOrder returns the IGNs of a tournament's players, manual seeds
+	// first and everyone else by personal best.
+	SeedOrder(ctx context.Context, tournamentID string) ([]string, error)
+	SetSeeds(ctx context.Context, tournamentID string, igns []string) error
+	// SetStatus records that a player withdrew or was disqualified. Such
+	// players are left out of SeedOrder.
+	SetStatus(ctx context.Context, tournamentID, ign, status string) error
+	// CheckIn checks in the player with the given IGN or, failing that,
+	// Discord name and returns their IGN.
+	CheckIn(ctx context.Context, tournamentID, name string) (string, error)
+	// CheckedIn returns the IGNs of the players who checked in.
+	CheckedIn(ctx context.Context, tournamentID string) ([]string, error)
+}
+
+// ResultRepository stores game results and the final results of completed
+// tournaments.
+type ResultRepository interface {
+	// RecordGame saves a submission and the results of its game together
+	// with the tournament state after it, in one transaction. If the game
+	// completed the tournament, completed holds the final results, which
+	// are saved in place of the state and mark the tournament as completed.
+	// A forfeit also sets the status of the player who forfeited.
+	RecordGame(ctx context.Context, tournamentID string, submission Submission, results []formats.GameResult, state []byte, completed *TournamentResults) error
+	// FindSubmission returns the earlier submission with the given
+	// idempotency key or, if key is empty, the latest submission of the game
+	// that hasn't been voided. It returns nil if there is none.
+	FindSubmission(ctx context.Context, tournamentID, key, gameID string) (*Submission, error)
+	// Submissions returns the submissions of a tournament that haven't been
+	// voided, in the order they were made.
+	Submissions(ctx context.Context, tournamentID string) ([]Submission, error)
+	// Correct applies corrections to earlier submissions and replaces the
+	// game results of the tournament with results, which were replayed from
+	// the corrected submissions. state and completed are saved like they
+	// are by RecordGame, reopening a completed tournament if needed.
+	Correct(ctx context.Context, tournamentID string, corrections []Correction, results []formats.GameResult, state []byte, completed *TournamentResults) error
+	// GameResults returns a tournament's game results in the order they
+	// were recorded.
+	GameResults(ctx context.Context, tournamentID string) ([]formats.GameResult, error)
+	Get(ctx context.Context, tournamentID string) (*TournamentResults, error)
+}
+
+// Submission is a game result as it was submitted, with the players in the
+// order they were listed, or a forfeit. A forfeit has the forfeit action as
+// its Kind, the player who forfeited as its only player and no game ID.
+type Submission struct {
+	ID      int64
+	Kind    string
+	GameID  string
+	Key     string
+	Players []string
+	Times   []uint64
+}
+
+type SavedState struct {
+	TournamentID string
+	Format       string
+	Data         []byte
+}
+
+// DeadlineRepository stores the deadlines of pending matches.
+type DeadlineRepository interface {
+	List(ctx context.Context, tournamentID string) ([]MatchDeadline, error)
+	// Save creates or replaces the deadline of a match.
+	Save(ctx context.Context, tournamentID string, deadline MatchDeadline) error
+	Delete(ctx context.Context, tournamentID string, matchIDs []string) error
+}
+
+// Repositories groups the storage the TournamentManager works with.
+type Repositories struct {
+	Tournaments TournamentRepository
+	Players     PlayerRepository
+	Results     ResultRepository
+	Deadlines   DeadlineRepository
+}
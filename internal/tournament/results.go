@@ -0,0 +1,55 @@
+package tournament
+
+import (
+	"context"
+	"time"
+	"tournament-manager/internal/tournament/formats"
+)
+
+type MatchRecord struct {
+	MatchID string   `json:"match_id"`
+	Round   int      `json:"round"`
+	Bracket string   `json:"bracket,omitempty"`
+	Players []string `json:"players"`
+	Winner  string   `json:"winner"`
+	BestOf  int      `json:"best_of"`
+}
+
+type TournamentResults struct {
+	TournamentID string              `json:"tournament_id"`
+	Name         string              `json:"name"`
+	Format       string              `json:"format"`
+	Winner       string              `json:"winner"`
+	CompletedAt  time.Time           `json:"completed_at"`
+	Placements   []formats.Placement `json:"placements"`
+	Matches      []MatchRecord       `json:"matches"`
+}
+
+// finalResults collects the matches and placements of a completed
+// tournament.
+func finalResults(tournamentID string, state formats.Format) *TournamentResults {
+	results := &TournamentResults{
+		TournamentID: tournamentID,
+		Winner:       state.GetWinner(),
+		Placements:   state.GetPlacements(),
+		Matches:      []MatchRecord{},
+	}
+
+	for _, match := range state.GetMatchHistory() {
+		results.Matches = append(results.Matches, MatchRecord{
+			MatchID: match.ID,
+			Round:   match.Round,
+			Bracket: match.Bracket,
+			Players: match.Participants(),
+			Winner:  match.Winner,
+			BestOf:  max(match.BestOf, 1),
+		})
+	}
+
+	return results
+}
+
+// GetTournamentResults reads the saved results of a completed tournament.
+func (tm *TournamentManager) GetTournamentResults(ctx context.Context, tournamentID string) (*TournamentResults, error) {
+	return tm.results.Get(ctx, tournamentID)
+}
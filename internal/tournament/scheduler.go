@@ -0,0 +1,84 @@
+package tournament
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// StartsAt returns when a scheduled tournament starts on its own: at its
+// date, or when check-in closes if it is set to start then.
+func (t Tournament) StartsAt() time.Time {
+	if t.CheckIn != nil && t.CheckIn.StartWhenClosed {
+		_, closes := t.CheckIn.Window(t.Date)
+		return closes
+	}
+	return time.Unix(int64(t.Date), 0)
+}
+
+// StartScheduler starts tournaments as they become due and enforces match
+// deadlines, checking every interval until ctx is cancelled. Tournaments
+// that are already due, for example because the server was down at their
+// start time, are started on the first check.
+func (tm *TournamentManager) StartScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			tm.StartDueTournaments(ctx, time.Now())
+			tm.EnforceDeadlines(ctx, time.Now())
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// StartDueTournaments starts every scheduled tournament whose start time is
+// not after now and returns the IDs of the ones that started. A tournament
+// that fails to start is reported with a tournament_start_failed event and
+// not retried, so it is left for an admin to start by hand.
+func (tm *TournamentManager) StartDueTournaments(ctx context.Context, now time.Time) []string {
+	tournaments, err := tm.tournaments.Scheduled(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get scheduled tournaments", "error", err)
+		return nil
+	}
+
+	var started []string
+	for _, tournament := range tournaments {
+		if tournament.StartsAt().After(now) || tm.IsActive(tournament.ID) || tm.startFailed(tournament.ID) {
+			continue
+		}
+
+		dropped, err := tm.StartTournament(ctx, tournament.ID)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to start scheduled tournament", "tournament_id", tournament.ID, "error", err)
+
+			tm.mu.Lock()
+			tm.failedStarts[tournament.ID] = true
+			tm.mu.Unlock()
+
+			tm.publish(tournament.ID, EventTournamentStartFailed, map[string]interface{}{
+				"error": err.Error(),
+			})
+			continue
+		}
+
+		slog.InfoContext(ctx, "Started scheduled tournament", "tournament_id", tournament.ID, "dropped", dropped)
+		started = append(started, tournament.ID)
+	}
+
+	return started
+}
+
+func (tm *TournamentManager) startFailed(tournamentID string) bool {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	return tm.failedStarts[tournamentID]
+}
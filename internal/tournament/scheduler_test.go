@@ -0,0 +1,88 @@
+package tournament_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+	"tournament-manager/internal/tournament"
+	"tournament-manager/internal/tournament/formats"
+)
+
+func TestSchedulerStartsDueTournaments(t *testing.T) {
+	tm := tournament.NewTournamentManager(tournament.NewMemoryRepositories())
+
+	now := time.Now()
+	due, _ := tm.CreateTournament(t.Context(), "due", uint64(now.Add(-time.Minute).Unix()), "solo_single_elim", formats.Options{}, nil)
+	later, _ := tm.CreateTournament(t.Context(), "later", uint64(now.Add(time.Hour).Unix()), "solo_single_elim", formats.Options{}, nil)
+
+	for _, id := range []string{due, later} {
+		for i, ign := range []string{"senez", "kha0x"} {
+			if err := tm.Signup(t.Context(), ign, ign, uint64(120000+i*1000), id); err != nil {
+				t.Fatalf("failed to sign up %v: %v", ign, err)
+			}
+		}
+	}
+
+	started := tm.StartDueTournaments(t.Context(), now)
+	if !slices.Equal(started, []string{due}) {
+		t.Errorf("unexpected started tournaments, expected %v, got %v", []string{due}, started)
+	}
+
+	if !tm.IsActive(due) || tm.IsActive(later) {
+		t.Errorf("unexpected active tournaments, expected only %v to be active", due)
+	}
+}
+
+func TestSchedulerWaitsForDateAfterCheckIn(t *testing.T) {
+	tm, id := newCheckInTournament(t, 30*time.Minute)
+
+	tournamentDate := time.Now().Add(30 * time.Minute)
+	if started := tm.StartDueTournaments(t.Context(), tournamentDate.Add(-15*time.Minute)); len(started) != 0 {
+		t.Errorf("unexpected start before check-in closes: %v", started)
+	}
+
+	for _, ign := range []string{"senez", "kha0x"} {
+		if _, err := tm.CheckIn(t.Context(), id, ign); err != nil {
+			t.Fatalf("failed to check in %v: %v", ign, err)
+		}
+	}
+
+	// Without StartWhenClosed the tournament waits for its date.
+	if started := tm.StartDueTournaments(t.Context(), tournamentDate.Add(-5*time.Minute)); len(started) != 0 {
+		t.Errorf("unexpected start before the tournament date: %v", started)
+	}
+
+	if started := tm.StartDueTournaments(t.Context(), tournamentDate.Add(time.Second)); !slices.Equal(started, []string{id}) {
+		t.Errorf("unexpected started tournaments, expected %v, got %v", []string{id}, started)
+	}
+}
+
+func TestSchedulerReportsFailedStarts(t *testing.T) {
+	tm := tournament.NewTournamentManager(tournament.NewMemoryRepositories())
+
+	id, _ := tm.CreateTournament(t.Context(), "empty", uint64(time.Now().Add(-time.Minute).Unix()), "solo_single_elim", formats.Options{}, nil)
+
+	events, unsubscribe := tm.Subscribe(id)
+	defer unsubscribe()
+
+	if started := tm.StartDueTournaments(t.Context(), time.Now()); len(started) != 0 {
+		t.Errorf("unexpected started tournaments: %v", started)
+	}
+
+	select {
+	case event := <-events:
+		if event.Type != tournament.EventTournamentStartFailed {
+			t.Errorf("unexpected event, expected %v, got %v", tournament.EventTournamentStartFailed, event.Type)
+		}
+	default:
+		t.Errorf("expected a %v event", tournament.EventTournamentStartFailed)
+	}
+
+	// A failed start is left to an admin rather than retried.
+	tm.StartDueTournaments(t.Context(), time.Now())
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event on retry: %v", event.Type)
+	default:
+	}
+}
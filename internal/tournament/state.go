@@ -0,0 +1,65 @@
+package tournament
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+	"tournament-manager/internal/tournament/formats"
+)
+
+// saveState writes the current state of an active tournament so it can be
+// restored by LoadActiveTournaments after a restart.
+func (tm *TournamentManager) saveState(ctx context.Context, tournamentID string, state formats.Format) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode tournament state: %w", err)
+	}
+
+	return tm.tournaments.SaveState(ctx, tournamentID, data)
+}
+
+// LoadActiveTournaments restores every tournament that was still in progress
+// when the server last stopped.
+func (tm *TournamentManager) LoadActiveTournaments(ctx context.Context) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	states, err := tm.tournaments.SavedStates(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, saved := range states {
+		state, err := formats.Restore(saved.Format, saved.Data)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to restore tournament", "tournament_id", saved.TournamentID, "format", saved.Format, "error", err)
+			continue
+		}
+
+		tm.activeTournaments[saved.TournamentID] = state
+		tm.syncDeadlines(ctx, saved.TournamentID, state, time.Now())
+		slog.InfoContext(ctx, "Tournament restored", "tournament_id", saved.TournamentID, "format", saved.Format)
+	}
+
+	return nil
+}
+
+// Shutdown saves the state of every active tournament and closes the event
+// bus, ending open event streams. It is called once the server has stopped
+// taking requests.
+func (tm *TournamentManager) Shutdown(ctx context.Context) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	for tournamentID, state := range tm.activeTournaments {
+		if err := tm.saveState(ctx, tournamentID, state); err != nil {
+			slog.ErrorContext(ctx, "Failed to persist tournament state", "tournament_id", tournamentID, "error", err)
+			continue
+		}
+		slog.InfoContext(ctx, "Tournament state saved", "tournament_id", tournamentID)
+	}
+
+	tm.events.Close()
+}
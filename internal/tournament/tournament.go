@@ -4,34 +4,52 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"tournament-manager/internal/database"
+	"time"
+	"tournament-manager/internal/tournament/formats"
+)
+
+const (
+	StatusScheduled  = "scheduled"
+	StatusInProgress = "in_progress"
+	StatusCompleted  = "completed"
+	StatusStopped    = "stopped"
 )
 
 type Tournament struct {
-	ID           string
-	Name         string
-	Date         uint64
-	Format       string
+	ID      string
+	Name    string
+	Date    uint64
+	Format  string
+	Options formats.Options
+	Status  string
+	Seeding []string
+	// CheckIn is nil for tournaments without check-in, which start with
+	// every player signed up.
+	CheckIn      *CheckIn
+	Winner       string
+	CompletedAt  *time.Time
 	Participants []Player
 }
 
-var AvailableFormats = map[string]string{
-	"solo_single_elim": "Solo Single Elimination",
-}
+// AvailableFormats maps every registered format name to its display name.
+var AvailableFormats = formats.Available()
 
-func CreateTournament(name string, date uint64, format string) (string, error) {
-	slog.Debug("inserting values", "name", name, "date", date, "format", format)
+func (tm *TournamentManager) CreateTournament(ctx context.Context, name string, date uint64, format string, opts formats.Options, checkIn *CheckIn) (string, error) {
+	slog.DebugContext(ctx, "inserting values", "name", name, "date", date, "format", format, "options", opts, "check_in", checkIn)
 
 	if _, exists := AvailableFormats[format]; !exists {
 		return "", fmt.Errorf("unsupported format: %s", format)
 	}
 
-	insertQuery := "INSERT INTO Tournament (name, date, format) VALUES ($1, $2, $3) RETURNING id"
+	if checkIn != nil {
+		if err := checkIn.Validate(); err != nil {
+			return "", err
+		}
+	}
 
-	var id string
-	err := database.DB.QueryRow(context.Background(), insertQuery, name, date, format).Scan(&id)
+	id, err := tm.tournaments.Create(ctx, name, date, format, opts, checkIn)
 	if err != nil {
-		slog.Warn(err.Error())
+		slog.WarnContext(ctx, err.Error())
 		return "", err
 	}
 
@@ -2,13 +2,25 @@ package util
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
 	"strconv"
 	"strings"
 )
 
+// ParseTime parses a time written as m:ss.f, with up to three digits after
+// the point, into milliseconds.
 func ParseTime(pb string) (uint64, error) {
 	pb = strings.TrimSpace(pb)
+
+	// strconv accepts a sign on every part, which would let a negative part
+	// wrap around once the time is converted to unsigned.
+	if strings.ContainsAny(pb, "+-") {
+		err := errors.New("invalid time format: times cannot have a sign")
+		slog.Warn(err.Error())
+		return 0, err
+	}
+
 	minutes, rest, found := strings.Cut(pb, ":")
 	if !found {
 		err := errors.New("invalid time format: failed to parse minutes")
@@ -41,7 +53,10 @@ func ParseTime(pb string) (uint64, error) {
 		return 0, err
 	}
 
-	millisecondsInt, err := strconv.ParseInt(rest[0:1], 10, 64)
+	// The fraction is read to the millisecond, so "2:00.5" is 500 ms past
+	// two minutes. Further digits are ignored.
+	fraction := (rest + "00")[:3]
+	millisecondsInt, err := strconv.ParseInt(fraction, 10, 64)
 	if err != nil {
 		slog.Warn(err.Error())
 		return 0, err
@@ -49,3 +64,8 @@ func ParseTime(pb string) (uint64, error) {
 
 	return uint64(minutesInt*60*1000 + secondsInt*1000 + millisecondsInt), nil
 }
+
+// FormatTime formats milliseconds as m:ss.mmm.
+func FormatTime(ms uint64) string {
+	return fmt.Sprintf("%d:%02d.%03d", ms/60000, ms/1000%60, ms%1000)
+}
@@ -0,0 +1,41 @@
+package util
+
+import "testing"
+
+func TestParseTime(t *testing.T) {
+	for input, expected := range map[string]uint64{
+		"2:00.5":    120500,
+		"2:00.05":   120050,
+		"2:00.005":  120005,
+		"1:59.999":  119999,
+		"0:07.1234": 7123,
+		" 10:00.0 ": 600000,
+	} {
+		ms, err := ParseTime(input)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %v", input, err)
+			continue
+		}
+
+		if ms != expected {
+			t.Errorf("unexpected time for %q, expected %v, got %v", input, expected, ms)
+		}
+
+		formatted := FormatTime(ms)
+		if again, err := ParseTime(formatted); err != nil || again != ms {
+			t.Errorf("unexpected round trip for %q through %q, expected %v, got %v, error %v", input, formatted, ms, again, err)
+		}
+	}
+
+	for _, input := range []string{"2:00", "200.5", "2:00.", "a:00.5", "2:00.x", "0:-5.000", "-1:00.0", "1:00.-5", "+1:00.0"} {
+		if _, err := ParseTime(input); err == nil {
+			t.Errorf("expected an error parsing %q", input)
+		}
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	if formatted := FormatTime(120500); formatted != "2:00.500" {
+		t.Errorf("unexpected formatted time, expected %v, got %v", "2:00.500", formatted)
+	}
+}
@@ -0,0 +1,182 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+	"tournament-manager/internal/database"
+	"tournament-manager/internal/tournament"
+)
+
+const (
+	maxAttempts    = 5
+	initialBackoff = time.Second
+)
+
+var client = &http.Client{Timeout: 10 * time.Second}
+
+var (
+	inflight         sync.WaitGroup
+	cancelDeliveries context.CancelFunc = func() {}
+	// stopped is closed once the dispatcher stops taking events, after
+	// which no more deliveries are added to inflight.
+	stopped chan struct{}
+)
+
+// Start forwards every tournament event to the webhooks subscribed to it
+// until the manager's event bus is closed, which delivers whatever is still
+// queued first. Cancelling ctx doesn't stop the dispatcher, so events
+// published while requests drain on shutdown still go out. Deliveries are
+// left for Shutdown to wait for.
+func Start(ctx context.Context) {
+	events, unsubscribe := tournament.Manager.SubscribeUnbounded("")
+	forward(ctx, events, unsubscribe, dispatch)
+}
+
+// forward runs the dispatcher, handing every event on events to handle
+// until the channel is closed.
+func forward(ctx context.Context, events <-chan tournament.Event, unsubscribe func(), handle func(context.Context, tournament.Event)) {
+	var deliveries context.Context
+	deliveries, cancelDeliveries = context.WithCancel(context.WithoutCancel(ctx))
+	stopped = make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		defer unsubscribe()
+
+		for event := range events {
+			handle(deliveries, event)
+		}
+	}()
+}
+
+// Shutdown waits for the dispatcher to stop and for the deliveries in
+// progress, including their retries, until ctx is done and then cancels
+// whatever is left.
+func Shutdown(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		if stopped != nil {
+			<-stopped
+		}
+		inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		slog.Warn("Cancelling webhook deliveries still in progress")
+		cancelDeliveries()
+		<-done
+	}
+}
+
+func dispatch(ctx context.Context, event tournament.Event) {
+	webhooks, err := listWithSecrets(event.TournamentID)
+	if err != nil {
+		slog.Error("Failed to load webhooks", "tournament_id", event.TournamentID, "error", err)
+		return
+	}
+
+	var payload []byte
+	for _, webhook := range webhooks {
+		if !webhook.wants(event.Type) {
+			continue
+		}
+
+		if payload == nil {
+			if payload, err = json.Marshal(event); err != nil {
+				slog.Error("Failed to encode event", "tournament_id", event.TournamentID, "type", event.Type, "error", err)
+				return
+			}
+		}
+
+		inflight.Add(1)
+		go func() {
+			defer inflight.Done()
+			deliver(ctx, webhook, event.Type, payload)
+		}()
+	}
+}
+
+// deliver posts the payload to a webhook, retrying with exponential backoff
+// until it gets a 2xx response or runs out of attempts. Every attempt is
+// written to the delivery log.
+func deliver(ctx context.Context, webhook Webhook, eventType tournament.EventType, payload []byte) {
+	backoff := initialBackoff
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		statusCode, err := post(ctx, webhook, eventType, payload)
+		logDelivery(webhook.ID, eventType, payload, attempt, statusCode, err)
+
+		if err == nil {
+			slog.Debug("Webhook delivered", "webhook_id", webhook.ID, "type", eventType, "attempt", attempt)
+			return
+		}
+
+		slog.Warn("Webhook delivery failed", "webhook_id", webhook.ID, "type", eventType, "attempt", attempt, "error", err)
+
+		if attempt == maxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	slog.Error("Giving up on webhook delivery", "webhook_id", webhook.ID, "type", eventType)
+}
+
+func post(ctx context.Context, webhook Webhook, eventType tournament.EventType, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", string(eventType))
+	req.Header.Set("X-Webhook-Signature", "sha256="+sign(webhook.Secret, payload))
+
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+func logDelivery(webhookID string, eventType tournament.EventType, payload []byte, attempt int, statusCode int, deliveryErr error) {
+	var status *int
+	if statusCode != 0 {
+		status = &statusCode
+	}
+
+	var errMsg *string
+	if deliveryErr != nil {
+		msg := deliveryErr.Error()
+		errMsg = &msg
+	}
+
+	sql := `
+		INSERT INTO WebhookDelivery (webhook_id, event_type, payload, attempt, status_code, error, delivered)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	if _, err := database.DB.Exec(context.Background(), sql, webhookID, eventType, payload, attempt, status, errMsg, deliveryErr == nil); err != nil {
+		slog.Warn("Failed to log webhook delivery", "webhook_id", webhookID, "error", err)
+	}
+}
@@ -0,0 +1,188 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+	"tournament-manager/internal/database"
+	"tournament-manager/internal/tournament"
+)
+
+// Webhook is a subscription to the events of one tournament. An empty Events
+// filter subscribes to every event.
+type Webhook struct {
+	ID           string    `json:"id"`
+	TournamentID string    `json:"tournament_id"`
+	URL          string    `json:"url"`
+	Events       []string  `json:"events"`
+	Secret       string    `json:"secret,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Delivery struct {
+	ID         int64     `json:"id"`
+	WebhookID  string    `json:"webhook_id"`
+	EventType  string    `json:"event_type"`
+	Attempt    int       `json:"attempt"`
+	StatusCode *int      `json:"status_code"`
+	Error      *string   `json:"error"`
+	Delivered  bool      `json:"delivered"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (w *Webhook) wants(eventType tournament.EventType) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, string(eventType))
+}
+
+// sign returns the hex encoded HMAC-SHA256 of the payload, which receivers
+// compare against the X-Webhook-Signature header.
+func sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func validate(rawURL string, events []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook url: %v", rawURL)
+	}
+
+	for _, event := range events {
+		if !slices.Contains(tournament.EventTypes, tournament.EventType(event)) {
+			return fmt.Errorf("unknown event type: %v", event)
+		}
+	}
+
+	return nil
+}
+
+// Create subscribes a URL to the events of a tournament. A secret is
+// generated if none is given; it is only ever returned here.
+func Create(tournamentID, rawURL string, events []string, secret string) (*Webhook, error) {
+	if err := validate(rawURL, events); err != nil {
+		return nil, err
+	}
+
+	if events == nil {
+		events = []string{}
+	}
+
+	if secret == "" {
+		var err error
+		if secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	webhook := Webhook{
+		TournamentID: tournamentID,
+		URL:          rawURL,
+		Events:       events,
+		Secret:       secret,
+	}
+
+	sql := `
+		INSERT INTO Webhook (tournament_id, url, events, secret)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	row := database.DB.QueryRow(context.Background(), sql, tournamentID, rawURL, events, secret)
+	if err := row.Scan(&webhook.ID, &webhook.CreatedAt); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return &webhook, nil
+}
+
+// List returns the webhooks of a tournament without their secrets.
+func List(tournamentID string) ([]Webhook, error) {
+	return query("SELECT id, tournament_id, url, events, '', created_at FROM Webhook WHERE tournament_id = $1 ORDER BY created_at", tournamentID)
+}
+
+func listWithSecrets(tournamentID string) ([]Webhook, error) {
+	return query("SELECT id, tournament_id, url, events, secret, created_at FROM Webhook WHERE tournament_id = $1", tournamentID)
+}
+
+func query(sql string, tournamentID string) ([]Webhook, error) {
+	rows, err := database.DB.Query(context.Background(), sql, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []Webhook{}
+	for rows.Next() {
+		var webhook Webhook
+		if err := rows.Scan(&webhook.ID, &webhook.TournamentID, &webhook.URL, &webhook.Events, &webhook.Secret, &webhook.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func Delete(tournamentID, webhookID string) error {
+	sql := "DELETE FROM Webhook WHERE id = $1 AND tournament_id = $2"
+	tag, err := database.DB.Exec(context.Background(), sql, webhookID, tournamentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("webhook %s not found", webhookID)
+	}
+
+	return nil
+}
+
+// Deliveries returns the delivery log of a webhook, latest attempt first.
+func Deliveries(tournamentID, webhookID string) ([]Delivery, error) {
+	sql := `
+		SELECT d.id, d.webhook_id, d.event_type, d.attempt, d.status_code, d.error, d.delivered, d.created_at
+		FROM WebhookDelivery d
+		JOIN Webhook w ON w.id = d.webhook_id
+		WHERE w.tournament_id = $1 AND d.webhook_id = $2
+		ORDER BY d.id DESC
+		LIMIT 100
+	`
+	rows, err := database.DB.Query(context.Background(), sql, tournamentID, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []Delivery{}
+	for rows.Next() {
+		var delivery Delivery
+		if err := rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventType, &delivery.Attempt, &delivery.StatusCode, &delivery.Error, &delivery.Delivered, &delivery.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,83 @@
+package webhooks
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"tournament-manager/internal/tournament"
+)
+
+func TestSign(t *testing.T) {
+	// echo -n '{"type":"tournament_started"}' | openssl dgst -sha256 -hmac secret
+	expected := "69dbc5099d0da3834759d5e73411ba0bbc807f07c581b7f1b84bf657500a9695"
+	got := sign("secret", []byte(`{"type":"tournament_started"}`))
+	if got != expected {
+		t.Errorf("unexpected signature, expected %v, got %v", expected, got)
+	}
+}
+
+func TestWants(t *testing.T) {
+	all := Webhook{}
+	if !all.wants(tournament.EventMatchCreated) {
+		t.Errorf("expected a webhook without a filter to want every event")
+	}
+
+	filtered := Webhook{Events: []string{string(tournament.EventTournamentCompleted)}}
+	if filtered.wants(tournament.EventMatchCreated) {
+		t.Errorf("expected the filter to exclude %v", tournament.EventMatchCreated)
+	}
+
+	if !filtered.wants(tournament.EventTournamentCompleted) {
+		t.Errorf("expected the filter to include %v", tournament.EventTournamentCompleted)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := validate("https://example.com/hook", []string{"tournament_started"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := validate("ftp://example.com/hook", nil); err == nil {
+		t.Errorf("expected an error for a non http url")
+	}
+
+	if err := validate("https://example.com/hook", []string{"unknown"}); err == nil {
+		t.Errorf("expected an error for an unknown event type")
+	}
+}
+
+func TestDispatcherDeliversQueuedEventsOnShutdown(t *testing.T) {
+	bus := tournament.NewEventBus()
+	events, unsubscribe := bus.SubscribeUnbounded("")
+
+	var mu sync.Mutex
+	var handled []tournament.EventType
+
+	ctx, cancel := context.WithCancel(t.Context())
+	forward(ctx, events, unsubscribe, func(_ context.Context, event tournament.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled = append(handled, event.Type)
+	})
+
+	published := 100
+	for range published / 2 {
+		bus.Publish(tournament.Event{Type: tournament.EventMatchCreated, TournamentID: "id"})
+	}
+
+	// Requests still draining after the signal keep publishing until the
+	// manager closes the bus.
+	cancel()
+	for range published / 2 {
+		bus.Publish(tournament.Event{Type: tournament.EventResultSubmitted, TournamentID: "id"})
+	}
+	bus.Close()
+
+	Shutdown(t.Context())
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(handled) != published {
+		t.Errorf("unexpected number of dispatched events, expected %v, got %v", published, len(handled))
+	}
+}